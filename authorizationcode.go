@@ -0,0 +1,181 @@
+package ecobee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrScopeMismatch is returned when the scope granted by the ecobee server
+// does not cover the scope that was requested.
+var ErrScopeMismatch = errors.New("scope mismatch")
+
+// ErrStateMismatch is returned by the handler returned by
+// AuthorizationCodeHandler when the state returned by the ecobee server is
+// rejected.
+var ErrStateMismatch = errors.New("state mismatch")
+
+// Covers reports whether a granted scope s covers the requested scope. The
+// smartWrite scope covers the smartRead scope.
+func (s Scope) Covers(requested Scope) bool {
+	if s == requested {
+		return true
+	}
+
+	return s == ScopeSmartWrite && requested == ScopeSmartRead
+}
+
+// ValidateScope returns an error satisfying errors.Is(err, ErrScopeMismatch)
+// if the scope granted in the response does not cover the requested scope.
+func (t *TokensSuccessResponse) ValidateScope(requested Scope) error {
+	if !t.scope.Covers(requested) {
+		return fmt.Errorf("%s: requested %q, granted %q: %w", tokenEndpoint, requested, t.scope, ErrScopeMismatch)
+	}
+
+	return nil
+}
+
+// AuthorizationCodeURL returns the URL of the ecobee authorization page the
+// user must be redirected to in order to authorize an application using the
+// authorization code method. After the user authorizes the application the
+// ecobee server redirects to redirectURI with the code and state query
+// parameters.
+//
+// state should be an unguessable value bound to the user's session. It is
+// returned unchanged and must be verified to protect against CSRF.
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/auth/authz-code-authorization.shtml
+func (c *Client) AuthorizationCodeURL(applicationKey string, redirectURI string, scope Scope, state string) string {
+	queryParameters := url.Values{}
+	queryParameters.Set("response_type", "code")
+	queryParameters.Set("client_id", applicationKey)
+	queryParameters.Set("redirect_uri", redirectURI)
+	queryParameters.Set("scope", string(scope))
+	queryParameters.Set("state", state)
+
+	return fmt.Sprintf("%s%s?%s", c.apiBaseURL, authorizeEndpoint, queryParameters.Encode())
+}
+
+// ExchangeAuthorizationCode requests access and refresh tokens in exchange
+// for the authorization code returned to the redirect URI. redirectURI must
+// match the one passed to AuthorizationCodeURL.
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/auth/authz-code-authorization.shtml
+func (c *Client) ExchangeAuthorizationCode(ctx context.Context, applicationKey string, code string, redirectURI string) (*TokensSuccessResponse, error) {
+	queryParameters := url.Values{}
+	queryParameters.Set("grant_type", "authorization_code")
+	queryParameters.Set("code", code)
+	queryParameters.Set("redirect_uri", redirectURI)
+	queryParameters.Set("client_id", applicationKey)
+
+	issuedAt := time.Now().UTC()
+
+	resp, err := c.post(ctx, tokenEndpoint, fmt.Sprintf("%s%s", c.apiBaseURL, tokenEndpoint), nil, queryParameters, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tokenEndpoint, err)
+	}
+
+	defer func() {
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}()
+
+	tokensResponse := TokensSuccessResponse{}
+	tokensResponse.issuedAt = issuedAt
+
+	if err := processAuthorizationResponse(tokenEndpoint, resp, &tokensResponse); err != nil {
+		return nil, err
+	}
+
+	return &tokensResponse, nil
+}
+
+type authorizationCodeHandler struct {
+	client         *Client
+	applicationKey string
+	redirectURI    string
+	scope          Scope
+	validateState  func(r *http.Request, state string) bool
+	callback       func(w http.ResponseWriter, r *http.Request, token *oauth2.Token, err error)
+}
+
+// AuthorizationCodeHandler returns an http.Handler to be mounted at
+// redirectURI that completes the authorization code method.
+//
+// The handler rejects the request if validateState returns false for the
+// returned state. Otherwise it exchanges the returned code for tokens and
+// verifies the granted scope covers scope. In every case callback is invoked
+// exactly once with either the token or the error, and is responsible for
+// writing the response. validateState and callback are required; an error is
+// returned if either is nil.
+func (c *Client) AuthorizationCodeHandler(applicationKey string, redirectURI string, scope Scope, validateState func(r *http.Request, state string) bool, callback func(w http.ResponseWriter, r *http.Request, token *oauth2.Token, err error)) (http.Handler, error) {
+	if validateState == nil {
+		return nil, fmt.Errorf("%s: missing validateState", authorizeEndpoint)
+	}
+
+	if callback == nil {
+		return nil, fmt.Errorf("%s: missing callback", authorizeEndpoint)
+	}
+
+	return &authorizationCodeHandler{
+		client:         c,
+		applicationKey: applicationKey,
+		redirectURI:    redirectURI,
+		scope:          scope,
+		validateState:  validateState,
+		callback:       callback,
+	}, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *authorizationCodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	queryParameters := r.URL.Query()
+
+	if !h.validateState(r, queryParameters.Get("state")) {
+		h.callback(w, r, nil, fmt.Errorf("%s: %w", authorizeEndpoint, ErrStateMismatch))
+
+		return
+	}
+
+	if errorType := queryParameters.Get("error"); errorType != "" {
+		h.callback(w, r, nil, &AuthorizationError{
+			endpoint:         authorizeEndpoint,
+			errorType:        AuthorizationErrorType(errorType),
+			errorDescription: queryParameters.Get("error_description"),
+			errorURI:         queryParameters.Get("error_uri"),
+		})
+
+		return
+	}
+
+	code := queryParameters.Get("code")
+	if code == "" {
+		h.callback(w, r, nil, fmt.Errorf("%s: missing code", authorizeEndpoint))
+
+		return
+	}
+
+	tokensResponse, err := h.client.ExchangeAuthorizationCode(r.Context(), h.applicationKey, code, h.redirectURI)
+	if err != nil {
+		h.callback(w, r, nil, err)
+
+		return
+	}
+
+	if err := tokensResponse.ValidateScope(h.scope); err != nil {
+		h.callback(w, r, nil, err)
+
+		return
+	}
+
+	h.callback(w, r, tokensResponse.OAuth2Token(), nil)
+}
@@ -0,0 +1,79 @@
+package ecobee
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestAuthorizationCodeHandler(t *testing.T) {
+	validateState := func(r *http.Request, state string) bool {
+		return state == "state"
+	}
+
+	var callbackErr error
+
+	callback := func(w http.ResponseWriter, r *http.Request, token *oauth2.Token, err error) {
+		callbackErr = err
+
+		w.WriteHeader(http.StatusForbidden)
+	}
+
+	tests := []struct {
+		name          string
+		validateState func(r *http.Request, state string) bool
+		callback      func(w http.ResponseWriter, r *http.Request, token *oauth2.Token, err error)
+		wantErr       bool
+	}{
+		{
+			name:          "missing validateState",
+			validateState: nil,
+			callback:      callback,
+			wantErr:       true,
+		},
+		{
+			name:          "missing callback",
+			validateState: validateState,
+			callback:      nil,
+			wantErr:       true,
+		},
+		{
+			name:          "valid",
+			validateState: validateState,
+			callback:      callback,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, err := NewClient().AuthorizationCodeHandler("applicationKey", "https://localhost/callback", ScopeSmartRead, test.validateState, test.callback)
+			if test.wantErr {
+				if err == nil || handler != nil {
+					t.Fatalf("AuthorizationCodeHandler() = %v, %v, want an error", handler, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("AuthorizationCodeHandler() error = %v", err)
+			}
+
+			callbackErr = nil
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/callback?code=code&state=forged", nil))
+
+			if !errors.Is(callbackErr, ErrStateMismatch) {
+				t.Errorf("callback error = %v, want %v", callbackErr, ErrStateMismatch)
+			}
+
+			if recorder.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", recorder.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
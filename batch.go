@@ -0,0 +1,154 @@
+package ecobee
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+const (
+	// maxSelectionMatchThermostats is the maximum number of thermostat
+	// identifiers ecobee accepts in the selectionMatch of a selection of type
+	// thermostats.
+	maxSelectionMatchThermostats = 25
+
+	defaultBatchConcurrency = 4
+)
+
+// WithBatchConcurrency returns a function that initializes a Client with the
+// maximum number of batches sent concurrently when a selection of type
+// thermostats matches more than 25 thermostats. It defaults to 4.
+func WithBatchConcurrency(batchConcurrency int) func(*Client) {
+	return func(c *Client) {
+		c.batchConcurrency = batchConcurrency
+	}
+}
+
+// A BatchError describes the failures of a request that was split into
+// batches because its selection matched more than 25 thermostats. Batches
+// that are not listed succeeded.
+type BatchError struct {
+	endpoint string
+	errors   []error
+	matches  []string
+}
+
+// Error returns the string representation of a BatchError.
+func (e *BatchError) Error() string {
+	messages := make([]string, len(e.errors))
+
+	for i, err := range e.errors {
+		messages[i] = fmt.Sprintf("batch %q: %s", e.matches[i], err)
+	}
+
+	return fmt.Sprintf("%s: %d batches failed: %s", e.endpoint, len(e.errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed batches so errors.Is and errors.As
+// match any of them.
+func (e *BatchError) Unwrap() []error {
+	return append([]error(nil), e.errors...)
+}
+
+// Errors returns the errors of the failed batches.
+func (e *BatchError) Errors() []error {
+	return append([]error(nil), e.errors...)
+}
+
+// SelectionMatches returns the selectionMatch of the failed batches, in the
+// same order as Errors.
+func (e *BatchError) SelectionMatches() []string {
+	return append([]string(nil), e.matches...)
+}
+
+// splitSelection splits a selection of type thermostats matching more than 25
+// thermostats into selections matching at most 25 thermostats each. It returns
+// nil if selection does not need to be split.
+func splitSelection(selection *objects.Selection) []*objects.Selection {
+	if selection == nil || selection.SelectionType == nil || *selection.SelectionType != "thermostats" || selection.SelectionMatch == nil {
+		return nil
+	}
+
+	identifiers := []string{}
+
+	for _, identifier := range strings.Split(*selection.SelectionMatch, ",") {
+		if identifier = strings.TrimSpace(identifier); identifier != "" {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	if len(identifiers) <= maxSelectionMatchThermostats {
+		return nil
+	}
+
+	selections := []*objects.Selection{}
+
+	for start := 0; start < len(identifiers); start += maxSelectionMatchThermostats {
+		end := start + maxSelectionMatchThermostats
+		if end > len(identifiers) {
+			end = len(identifiers)
+		}
+
+		batchSelection := *selection
+		batchSelection.SelectionMatch = String(strings.Join(identifiers[start:end], ","))
+
+		selections = append(selections, &batchSelection)
+	}
+
+	return selections
+}
+
+// runBatches calls fn for every selection, running at most the Client's batch
+// concurrency calls at a time. Calls not yet started when ctx is done fail
+// with ctx's error.
+func (c *Client) runBatches(ctx context.Context, endpoint string, selections []*objects.Selection, fn func(ctx context.Context, i int, selection *objects.Selection) error) error {
+	concurrency := c.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	errs := make([]error, len(selections))
+	semaphore := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, selection := range selections {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			errs[i] = fn(ctx, i, selection)
+		}()
+	}
+
+	wg.Wait()
+
+	batchError := &BatchError{endpoint: endpoint}
+
+	for i, err := range errs {
+		if err != nil {
+			batchError.errors = append(batchError.errors, err)
+			batchError.matches = append(batchError.matches, *selections[i].SelectionMatch)
+		}
+	}
+
+	if len(batchError.errors) != 0 {
+		return batchError
+	}
+
+	return nil
+}
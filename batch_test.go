@@ -0,0 +1,225 @@
+package ecobee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+func TestThermostatBatches(t *testing.T) {
+	identifiers := make([]string, 60)
+
+	for i := range identifiers {
+		identifiers[i] = fmt.Sprintf("%03d", i)
+	}
+
+	tests := []struct {
+		name        string
+		failing     string
+		wantMatches []string
+	}{
+		{
+			name: "all batches succeed",
+		},
+		{
+			name:        "second batch fails",
+			failing:     identifiers[25],
+			wantMatches: []string{strings.Join(identifiers[25:50], ",")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mu := sync.Mutex{}
+			selectionMatches := []string{}
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				request := struct {
+					Selection objects.Selection `json:"selection"`
+				}{}
+
+				if err := json.Unmarshal([]byte(r.URL.Query().Get("json")), &request); err != nil {
+					t.Errorf("json.Unmarshal() error = %v", err)
+				}
+
+				selectionMatch := *request.Selection.SelectionMatch
+
+				mu.Lock()
+				selectionMatches = append(selectionMatches, selectionMatch)
+				mu.Unlock()
+
+				batchIdentifiers := strings.Split(selectionMatch, ",")
+
+				if test.failing != "" && batchIdentifiers[0] == test.failing {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, _ = w.Write([]byte(`{"status":{"code":2,"message":"Not authorized."}}`))
+
+					return
+				}
+
+				thermostatList := make([]objects.Thermostat, len(batchIdentifiers))
+
+				for i, identifier := range batchIdentifiers {
+					thermostatList[i].Identifier = String(identifier)
+				}
+
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"page":           objects.Page{Page: Int(1), TotalPages: Int(1), PageSize: Int(len(thermostatList)), Total: Int(len(thermostatList))},
+					"thermostatList": thermostatList,
+					"status":         objects.Status{Code: Int(0), Message: String("")},
+				})
+			})
+
+			thermostatResponse, err := client.Thermostat(context.Background(), &objects.Selection{
+				SelectionType:  String("thermostats"),
+				SelectionMatch: String(strings.Join(identifiers, ",")),
+			}, nil)
+
+			if len(selectionMatches) != 3 {
+				t.Fatalf("requests = %d, want 3", len(selectionMatches))
+			}
+
+			for _, selectionMatch := range selectionMatches {
+				if n := len(strings.Split(selectionMatch, ",")); n > maxSelectionMatchThermostats {
+					t.Errorf("selectionMatch has %d identifiers, want at most %d", n, maxSelectionMatchThermostats)
+				}
+			}
+
+			if test.failing == "" {
+				if err != nil {
+					t.Fatalf("Thermostat() error = %v", err)
+				}
+
+				thermostatList := thermostatResponse.ThermostatList()
+				if len(thermostatList) != len(identifiers) {
+					t.Fatalf("len(ThermostatList()) = %d, want %d", len(thermostatList), len(identifiers))
+				}
+
+				for i, thermostat := range thermostatList {
+					if *thermostat.Identifier != identifiers[i] {
+						t.Errorf("ThermostatList()[%d].Identifier = %s, want %s", i, *thermostat.Identifier, identifiers[i])
+					}
+				}
+
+				if total := *thermostatResponse.Page().Total; total != len(identifiers) {
+					t.Errorf("Page().Total = %d, want %d", total, len(identifiers))
+				}
+
+				return
+			}
+
+			if thermostatResponse == nil || len(thermostatResponse.ThermostatList()) != len(identifiers)-maxSelectionMatchThermostats {
+				t.Errorf("Thermostat() = %v, want the %d thermostats of the batches that succeeded", thermostatResponse, len(identifiers)-maxSelectionMatchThermostats)
+			}
+
+			batchError := &BatchError{}
+			if !errors.As(err, &batchError) {
+				t.Fatalf("Thermostat() error = %v, want a *BatchError", err)
+			}
+
+			if got := batchError.SelectionMatches(); strings.Join(got, "|") != strings.Join(test.wantMatches, "|") {
+				t.Errorf("SelectionMatches() = %q, want %q", got, test.wantMatches)
+			}
+
+			if got := len(batchError.Errors()); got != len(test.wantMatches) {
+				t.Errorf("len(Errors()) = %d, want %d", got, len(test.wantMatches))
+			}
+
+			apiError := &APIError{}
+			if !errors.As(err, &apiError) || apiError.StatusCode() != StatusCodeNotAuthorized {
+				t.Errorf("Thermostat() error = %v, want an *APIError with status code %d", err, StatusCodeNotAuthorized)
+			}
+		})
+	}
+}
+
+func TestThermostatsBatchPages(t *testing.T) {
+	identifiers := make([]string, 60)
+
+	for i := range identifiers {
+		identifiers[i] = fmt.Sprintf("%03d", i)
+	}
+
+	// The number of pages of every batch, keyed by its first identifier.
+	batchTotalPages := map[string]int{identifiers[0]: 3, identifiers[25]: 1, identifiers[50]: 2}
+
+	mu := sync.Mutex{}
+	requests := []string{}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Selection objects.Selection `json:"selection"`
+			Page      objects.Page      `json:"page"`
+		}{}
+
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("json")), &request); err != nil {
+			t.Errorf("json.Unmarshal() error = %v", err)
+		}
+
+		batchIdentifiers := strings.Split(*request.Selection.SelectionMatch, ",")
+		page, totalPages := *request.Page.Page, batchTotalPages[batchIdentifiers[0]]
+
+		mu.Lock()
+		requests = append(requests, fmt.Sprintf("%s/%d", batchIdentifiers[0], page))
+		mu.Unlock()
+
+		if page > totalPages {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"status":{"code":10,"message":"Invalid page."}}`))
+
+			return
+		}
+
+		pageSize := (len(batchIdentifiers) + totalPages - 1) / totalPages
+		start, end := (page-1)*pageSize, page*pageSize
+
+		if end > len(batchIdentifiers) {
+			end = len(batchIdentifiers)
+		}
+
+		thermostatList := []objects.Thermostat{}
+
+		for _, identifier := range batchIdentifiers[start:end] {
+			thermostatList = append(thermostatList, objects.Thermostat{Identifier: String(identifier)})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"page":           objects.Page{Page: Int(page), TotalPages: Int(totalPages), PageSize: Int(pageSize), Total: Int(len(batchIdentifiers))},
+			"thermostatList": thermostatList,
+			"status":         objects.Status{Code: Int(0), Message: String("")},
+		})
+	})
+
+	thermostats, err := client.ThermostatsAll(context.Background(), &objects.Selection{
+		SelectionType:  String("thermostats"),
+		SelectionMatch: String(strings.Join(identifiers, ",")),
+	})
+	if err != nil {
+		t.Fatalf("ThermostatsAll() error = %v", err)
+	}
+
+	if len(thermostats) != len(identifiers) {
+		t.Errorf("len(ThermostatsAll()) = %d, want %d", len(thermostats), len(identifiers))
+	}
+
+	seen := map[string]bool{}
+
+	for _, thermostat := range thermostats {
+		seen[*thermostat.Identifier] = true
+	}
+
+	if len(seen) != len(identifiers) {
+		t.Errorf("ThermostatsAll() returned %d distinct thermostats, want %d", len(seen), len(identifiers))
+	}
+
+	if len(requests) != 6 {
+		t.Errorf("requests = %v, want 6: 3 pages of the first batch, 1 of the second and 2 of the third", requests)
+	}
+}
@@ -1,7 +1,9 @@
 package ecobee
 
 import (
+	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -18,6 +20,15 @@ type client struct {
 	apiVersion        int
 	httpClient        *http.Client
 	customHTTPHeaders map[string]string
+	applicationKey    string
+	token             *oauth2.Token
+	tokenStore        TokenStore
+	retryPolicy       *RetryPolicy
+	rateLimiter       *rateLimiter
+	middleware        []Middleware
+	callMiddleware    []CallMiddleware
+	logger            *slog.Logger
+	batchConcurrency  int
 }
 
 type clientOptionalParameters func(*Client)
@@ -42,6 +53,45 @@ func NewClient(optionalParameters ...clientOptionalParameters) *Client {
 		optionalParameter(client)
 	}
 
+	if client.token != nil {
+		base := client.httpClient.Transport
+		if transport, ok := base.(*oauth2.Transport); ok {
+			base = transport.Base
+		}
+
+		refreshClient := &Client{
+			client: client.client,
+		}
+		refreshClient.httpClient = &http.Client{
+			Transport: base,
+			Timeout:   client.httpClient.Timeout,
+		}
+
+		client.httpClient = &http.Client{
+			Transport: &oauth2.Transport{
+				Source: newTokenSource(context.Background(), refreshClient, client.applicationKey, client.token),
+				Base:   base,
+			},
+			CheckRedirect: client.httpClient.CheckRedirect,
+			Jar:           client.httpClient.Jar,
+			Timeout:       client.httpClient.Timeout,
+		}
+	}
+
+	if client.tokenStore != nil {
+		if transport, ok := client.httpClient.Transport.(*oauth2.Transport); ok {
+			client.httpClient = &http.Client{
+				Transport: &oauth2.Transport{
+					Source: newPersistingTokenSource(transport.Source, client.tokenStore, client.token),
+					Base:   transport.Base,
+				},
+				CheckRedirect: client.httpClient.CheckRedirect,
+				Jar:           client.httpClient.Jar,
+				Timeout:       client.httpClient.Timeout,
+			}
+		}
+	}
+
 	return client
 }
 
@@ -62,7 +112,9 @@ func WithAPIVersion(apiVersion int) func(*Client) {
 }
 
 // WithCustomHTTPHeaders returns a function that initializes a Client with
-// custom HTTP headers.
+// custom HTTP headers. A custom Authorization header makes every request fail
+// if the Client authorizes requests through an oauth2 transport; see
+// WithMiddleware.
 func WithCustomHTTPHeaders(customHTTPHeaders map[string]string) func(*Client) {
 	return func(c *Client) {
 		c.customHTTPHeaders = customHTTPHeaders
@@ -77,7 +129,36 @@ func WithHTTPClient(httpClient *http.Client) func(*Client) {
 	}
 }
 
+// WithToken returns a function that initializes a Client with an ecobee
+// application key and a previously issued OAuth2 token. The Client
+// authorizes every request with the token and transparently refreshes it
+// through the ecobee refresh_token grant once it expires.
+//
+// WithToken takes precedence over any oauth2 transport configured through
+// WithHTTPClient. The HTTP client supplied through WithHTTPClient, if any, is
+// used as the underlying transport.
+func WithToken(applicationKey string, token *oauth2.Token) func(*Client) {
+	return func(c *Client) {
+		c.applicationKey = applicationKey
+		c.token = token
+	}
+}
+
+// WithTokenStore returns a function that initializes a Client with a
+// TokenStore. Every time the OAuth2 token is refreshed the rotated token is
+// saved to the store before the request that triggered the refresh is sent.
+//
+// The store is only used when the Client authorizes requests through an
+// oauth2 transport, i.e. when it is also initialized with WithToken or with an
+// HTTP client obtained from the https://github.com/golang/oauth2 package.
+func WithTokenStore(tokenStore TokenStore) func(*Client) {
+	return func(c *Client) {
+		c.tokenStore = tokenStore
+	}
+}
+
 // String returns a string representing an indented JSON encoding of the client.
+// The access and refresh tokens are redacted.
 func (c *Client) String() string {
 	temp := struct {
 		APIBaseURL           string    `json:""`
@@ -94,9 +175,9 @@ func (c *Client) String() string {
 	if transport, ok := c.httpClient.Transport.(*oauth2.Transport); ok {
 		if token, err := transport.Source.Token(); err == nil {
 			temp.TokenType = token.TokenType
-			temp.AccessToken = token.AccessToken
+			temp.AccessToken = redactString(token.AccessToken)
 			temp.AccessTokenExpiresOn = token.Expiry.UTC()
-			temp.RefreshToken = token.RefreshToken
+			temp.RefreshToken = redactString(token.RefreshToken)
 		}
 	}
 
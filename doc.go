@@ -6,8 +6,14 @@ https://www.ecobee.com/home/developer/api/introduction/index.shtml
 
 # Authentication
 
-By design, the ecobee Client accepts any http.Client so OAuth2 requests can be
-made by using the appropriate authenticated client.
+The ecobee Client can be initialized with an application key and a previously
+issued OAuth2 token using WithToken. The Client then authorizes every request
+and transparently refreshes the token through the ecobee refresh_token grant.
+Because ecobee rotates the refresh token on every refresh, pair WithToken with
+WithTokenStore so each rotated token is persisted the moment it is issued.
+
+Alternatively the ecobee Client accepts any http.Client so OAuth2 requests can
+be made by using the appropriate authenticated client.
 Use the https://github.com/golang/oauth2 package to obtain an http.Client which
 transparently authorizes requests.
 
@@ -18,84 +24,43 @@ can be created manually with NewClient.
 
 The below example illustrates how to:
 
-- Fetch a persisted OAuth2 token from a file in JSON format if one exists.
+- Load a persisted OAuth2 token from a file in JSON format if one exists.
 
 - Authorize an app and create the OAuth2 token using the ecobee PIN authorization method if no persisted OAuth2 token is found.
 
-- Create an http.Client using the OAuth2 token. The Client will auto-refresh the token as necessary.
+- Create an ecobee.Client from the application key and the OAuth2 token. The Client will auto-refresh the token as necessary.
 
-- Create an ecobee.Client.
+- Persist every auto-refreshed OAuth2 token to the same file as soon as ecobee rotates it.
 
 - Retrieve a selection of thermostat data for one or more thermostats.
 
-- Retrieve the OAuth2 token from the ecobee.Client (In case it was auto-refreshed).
-
-- Persist the OAuth2 token to a file in JSON format
-
 	package main
 
 	import (
 		"bufio"
 		"context"
-		"encoding/json"
+		"errors"
 		"flag"
 		"fmt"
-		"io/ioutil"
 		"os"
 		"time"
 
-		"golang.org/x/oauth2"
-
 		"github.com/sherif-fanous/go-ecobee"
 		"github.com/sherif-fanous/go-ecobee/objects"
 	)
 
 	var applicationKey string
 	var filename string
-	var oauth2Endpoint = oauth2.Endpoint{
-		AuthURL:  "https://api.ecobee.com/authorize",
-		TokenURL: "https://api.ecobee.com/token",
-	}
-
-	func persistToken(oauth2Token *oauth2.Token) error {
-		b, err := json.MarshalIndent(oauth2Token, "", " ")
-		if err != nil {
-			return err
-		}
-
-		if err := ioutil.WriteFile(filename, b, 0644); err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	func fetchToken() (*oauth2.Token, error) {
-		b, err := ioutil.ReadFile(filename)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, nil
-			}
-
-			return nil, err
-		}
-
-		oauth2Token := oauth2.Token{}
-
-		if err := json.Unmarshal(b, &oauth2Token); err != nil {
-			return nil, err
-		}
-
-		return &oauth2Token, nil
-	}
 
 	func main() {
 		flag.StringVar(&applicationKey, "key", "", "ecobee Application Key")
 		flag.StringVar(&filename, "file", "", "token persistent store path")
 		flag.Parse()
 
-		oauth2Token, err := fetchToken()
-		if err != nil {
+		tokenStore := ecobee.NewFileTokenStore(filename)
+
+		oauth2Token, err := tokenStore.Load()
+		if err != nil && !errors.Is(err, ecobee.ErrTokenNotFound) {
 			fmt.Println(err)
 
 			return
@@ -134,8 +99,6 @@ The below example illustrates how to:
 			fmt.Println("PINAuthorization...Step #2")
 			fmt.Println()
 
-			nowUTC := time.Now().UTC()
-
 			ctx = context.Background()
 			ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
 			defer cancel()
@@ -149,20 +112,16 @@ The below example illustrates how to:
 
 			fmt.Printf("TokensResponse %s\n", tokensResponse)
 
-			oauth2Token = &oauth2.Token{
-				TokenType:    tokensResponse.TokenType(),
-				AccessToken:  tokensResponse.AccessToken(),
-				Expiry:       nowUTC.Add(time.Second * time.Duration(tokensResponse.ExpiresIn())),
-				RefreshToken: tokensResponse.RefreshToken(),
-			}
-		}
+			oauth2Token = tokensResponse.OAuth2Token()
+
+			if err := tokenStore.Save(oauth2Token); err != nil {
+				fmt.Println(err)
 
-		oauth2Config := &oauth2.Config{
-			ClientID: applicationKey,
-			Endpoint: oauth2Endpoint,
+				return
+			}
 		}
 
-		client := ecobee.NewClient(ecobee.WithHTTPClient(oauth2Config.Client(context.Background(), oauth2Token)))
+		client := ecobee.NewClient(ecobee.WithToken(applicationKey, oauth2Token), ecobee.WithTokenStore(tokenStore))
 
 		ctx := context.Background()
 		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -182,15 +141,6 @@ The below example illustrates how to:
 		}
 
 		fmt.Printf("ThermostatResponse %s\n", thermostatResponse)
-
-		// Get the updated token
-		oauth2Token = client.OAuth2Token()
-
-		if err := persistToken(oauth2Token); err != nil {
-			fmt.Println(err)
-
-			return
-		}
 	}
 */
 package ecobee
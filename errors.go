@@ -1,23 +1,237 @@
 package ecobee
 
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidGrant is returned when the ecobee server rejects an
+	// authorization code or a refresh token because it is invalid, expired or
+	// has been revoked. The application must be re-authorized.
+	ErrInvalidGrant = errors.New("invalid grant")
+
+	// ErrAuthorizationExpired is returned when the authorization code issued
+	// for the PIN authorization method expires before the user installs the
+	// application within the ecobee Web Portal.
+	ErrAuthorizationExpired = errors.New("authorization expired")
+
+	// ErrNoRefreshToken is returned when a token needs to be refreshed but it
+	// does not carry a refresh token.
+	ErrNoRefreshToken = errors.New("no refresh token")
+
+	// ErrAuthorizationHeader is returned when a request of a Client that
+	// authorizes requests through an oauth2 transport carries an Authorization
+	// header, which the transport would silently overwrite.
+	ErrAuthorizationHeader = errors.New("authorization header set by middleware or custom headers")
+)
+
+// A StatusCode specifies an ecobee response status code.
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/general/response-codes.shtml
+type StatusCode int
+
+// Documented StatusCode values.
+const (
+	StatusCodeSuccess                    StatusCode = 0
+	StatusCodeAuthenticationFailed       StatusCode = 1
+	StatusCodeNotAuthorized              StatusCode = 2
+	StatusCodeProcessingError            StatusCode = 3
+	StatusCodeSerializationError         StatusCode = 4
+	StatusCodeInvalidRequestFormat       StatusCode = 5
+	StatusCodeTooManyThermostats         StatusCode = 6
+	StatusCodeValidationError            StatusCode = 7
+	StatusCodeInvalidFunction            StatusCode = 8
+	StatusCodeInvalidSelection           StatusCode = 9
+	StatusCodeInvalidPage                StatusCode = 10
+	StatusCodeFunctionError              StatusCode = 11
+	StatusCodePostNotSupported           StatusCode = 12
+	StatusCodeGetNotSupported            StatusCode = 13
+	StatusCodeAuthenticationTokenExpired StatusCode = 14
+	StatusCodeDuplicateData              StatusCode = 15
+	StatusCodeInvalidToken               StatusCode = 16
+)
+
+// Sentinel errors matching an APIError with the corresponding StatusCode
+// through errors.Is.
+var (
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	ErrNotAuthorized        = errors.New("not authorized")
+	ErrProcessing           = errors.New("processing error")
+	ErrSerialization        = errors.New("serialization error")
+	ErrInvalidRequestFormat = errors.New("invalid request format")
+	ErrTooManyThermostats   = errors.New("too many thermostats in selection match criteria")
+	ErrValidation           = errors.New("validation error")
+	ErrInvalidFunction      = errors.New("invalid function")
+	ErrInvalidSelection     = errors.New("invalid selection")
+	ErrInvalidPage          = errors.New("invalid page")
+	ErrFunction             = errors.New("function error")
+	ErrPostNotSupported     = errors.New("post not supported for request")
+	ErrGetNotSupported      = errors.New("get not supported for request")
+	ErrTokenExpired         = errors.New("authentication token has expired")
+	ErrDuplicateData        = errors.New("duplicate data violation")
+	ErrInvalidToken         = errors.New("invalid token")
+)
+
+var statusCodeErrors = map[StatusCode]error{
+	StatusCodeAuthenticationFailed:       ErrAuthenticationFailed,
+	StatusCodeNotAuthorized:              ErrNotAuthorized,
+	StatusCodeProcessingError:            ErrProcessing,
+	StatusCodeSerializationError:         ErrSerialization,
+	StatusCodeInvalidRequestFormat:       ErrInvalidRequestFormat,
+	StatusCodeTooManyThermostats:         ErrTooManyThermostats,
+	StatusCodeValidationError:            ErrValidation,
+	StatusCodeInvalidFunction:            ErrInvalidFunction,
+	StatusCodeInvalidSelection:           ErrInvalidSelection,
+	StatusCodeInvalidPage:                ErrInvalidPage,
+	StatusCodeFunctionError:              ErrFunction,
+	StatusCodePostNotSupported:           ErrPostNotSupported,
+	StatusCodeGetNotSupported:            ErrGetNotSupported,
+	StatusCodeAuthenticationTokenExpired: ErrTokenExpired,
+	StatusCodeDuplicateData:              ErrDuplicateData,
+	StatusCodeInvalidToken:               ErrInvalidToken,
+}
+
 // APIError describes errors returned by the ecobee server while making
 // requests
 type APIError struct {
-	errorString string
+	endpoint       string
+	method         string
+	url            string
+	httpStatus     string
+	httpStatusCode int
+	statusCode     StatusCode
+	statusMessage  string
 }
 
 // Error returns the string representation of an APIError.
 func (e *APIError) Error() string {
-	return e.errorString
+	return fmt.Sprintf("%s: %s %q: %s: code %d: %s", e.endpoint, e.method, e.url, e.httpStatus, e.statusCode, e.statusMessage)
+}
+
+// Is reports whether the APIError matches target. It allows the sentinel
+// errors such as ErrTokenExpired, ErrNotAuthorized and ErrValidation to be
+// used with errors.Is.
+func (e *APIError) Is(target error) bool {
+	err, ok := statusCodeErrors[e.statusCode]
+
+	return ok && err == target
+}
+
+// Endpoint returns the name of the API endpoint that returned the error.
+func (e *APIError) Endpoint() string {
+	return e.endpoint
+}
+
+// Method returns the HTTP method of the request that returned the error.
+func (e *APIError) Method() string {
+	return e.method
+}
+
+// URL returns the URL of the request that returned the error.
+func (e *APIError) URL() string {
+	return e.url
+}
+
+// HTTPStatusCode returns the HTTP status code of the response.
+func (e *APIError) HTTPStatusCode() int {
+	return e.httpStatusCode
+}
+
+// StatusCode returns the ecobee status code of the response.
+func (e *APIError) StatusCode() StatusCode {
+	return e.statusCode
 }
 
+// StatusMessage returns the ecobee status message of the response.
+func (e *APIError) StatusMessage() string {
+	return e.statusMessage
+}
+
+// An AuthorizationErrorType specifies an OAuth error returned by the ecobee
+// server while authorizing.
+type AuthorizationErrorType string
+
+// Supported AuthorizationErrorType values.
+const (
+	AuthorizationErrorTypeAccessDenied         AuthorizationErrorType = "access_denied"
+	AuthorizationErrorTypeAuthorizationExpired AuthorizationErrorType = "authorization_expired"
+	AuthorizationErrorTypeAuthorizationPending AuthorizationErrorType = "authorization_pending"
+	AuthorizationErrorTypeInvalidClient        AuthorizationErrorType = "invalid_client"
+	AuthorizationErrorTypeInvalidGrant         AuthorizationErrorType = "invalid_grant"
+	AuthorizationErrorTypeInvalidRequest       AuthorizationErrorType = "invalid_request"
+	AuthorizationErrorTypeSlowDown             AuthorizationErrorType = "slow_down"
+	AuthorizationErrorTypeUnauthorizedClient   AuthorizationErrorType = "unauthorized_client"
+)
+
 // AuthorizationError describes errors returned by the ecobee server while
 // authorizing
 type AuthorizationError struct {
-	errorString string
+	endpoint         string
+	method           string
+	url              string
+	httpStatus       string
+	httpStatusCode   int
+	errorType        AuthorizationErrorType
+	errorDescription string
+	errorURI         string
 }
 
 // Error returns the string representation of an AuthorizationError.
 func (e *AuthorizationError) Error() string {
-	return e.errorString
+	if e.url == "" {
+		return fmt.Sprintf("%s: %s: %s: %s", e.endpoint, e.errorType, e.errorDescription, e.errorURI)
+	}
+
+	return fmt.Sprintf("%s: %s %q: %s: %s: %s: %s", e.endpoint, e.method, e.url, e.httpStatus, e.errorType, e.errorDescription, e.errorURI)
+}
+
+// Is reports whether the AuthorizationError matches target. It allows
+// errors.Is(err, ErrInvalidGrant) to identify rejected authorization codes and
+// refresh tokens, and errors.Is(err, ErrAuthorizationExpired) to identify
+// expired PINs.
+func (e *AuthorizationError) Is(target error) bool {
+	switch target {
+	case ErrInvalidGrant:
+		return e.errorType == AuthorizationErrorTypeInvalidGrant
+	case ErrAuthorizationExpired:
+		return e.errorType == AuthorizationErrorTypeAuthorizationExpired
+	}
+
+	return false
+}
+
+// Endpoint returns the name of the API endpoint that returned the error.
+func (e *AuthorizationError) Endpoint() string {
+	return e.endpoint
+}
+
+// Method returns the HTTP method of the request that returned the error.
+func (e *AuthorizationError) Method() string {
+	return e.method
+}
+
+// URL returns the URL of the request that returned the error.
+func (e *AuthorizationError) URL() string {
+	return e.url
+}
+
+// HTTPStatusCode returns the HTTP status code of the response.
+func (e *AuthorizationError) HTTPStatusCode() int {
+	return e.httpStatusCode
+}
+
+// ErrorType returns the error's OAuth error type.
+func (e *AuthorizationError) ErrorType() AuthorizationErrorType {
+	return e.errorType
+}
+
+// ErrorDescription returns the error's description.
+func (e *AuthorizationError) ErrorDescription() string {
+	return e.errorDescription
+}
+
+// ErrorURI returns the error's URI.
+func (e *AuthorizationError) ErrorURI() string {
+	return e.errorURI
 }
@@ -0,0 +1,155 @@
+package ecobee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+// A FunctionBatch collects functions and an optional thermostat patch to send
+// in a single UpdateThermostat request, so they are applied atomically and in
+// order. Its methods return the batch so calls can be chained:
+//
+//	batch := ecobee.NewFunctionBatch().
+//		ResumeProgram(&ecobee.ResumeProgramParameters{ResumeAll: ecobee.Bool(true)}).
+//		SetHold(&ecobee.SetHoldParameters{HoldClimateRef: ecobee.String("away")}).
+//		UpdateSensor(&ecobee.UpdateSensorParameters{...})
+//
+//	updateThermostatResponse, err := client.SendFunctionBatch(ctx, selection, batch)
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/operations/post-update-thermostats.shtml
+type FunctionBatch struct {
+	thermostat *objects.Thermostat
+	functions  []objects.Function
+	errs       []error
+}
+
+// NewFunctionBatch returns an empty FunctionBatch.
+func NewFunctionBatch() *FunctionBatch {
+	return &FunctionBatch{}
+}
+
+// Thermostat sets the partial thermostat object to update along with the
+// functions. Only the non nil fields of thermostat are updated.
+func (b *FunctionBatch) Thermostat(thermostat *objects.Thermostat) *FunctionBatch {
+	b.thermostat = thermostat
+
+	return b
+}
+
+// Function appends a function verbatim. It allows functions without a typed
+// wrapper to be sent.
+func (b *FunctionBatch) Function(function objects.Function) *FunctionBatch {
+	b.functions = append(b.functions, function)
+
+	return b
+}
+
+// appendFunction appends the function built by function if validate succeeds.
+// Otherwise it records the validation error, which is returned by Err and
+// SendFunctionBatch.
+func (b *FunctionBatch) appendFunction(validate func() error, function func() objects.Function) *FunctionBatch {
+	if err := validate(); err != nil {
+		b.errs = append(b.errs, err)
+
+		return b
+	}
+
+	return b.Function(function())
+}
+
+// Acknowledge appends an acknowledge function. See Client.Acknowledge.
+func (b *FunctionBatch) Acknowledge(parameters *AcknowledgeParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// ControlPlug appends a controlPlug function. See Client.ControlPlug.
+func (b *FunctionBatch) ControlPlug(parameters *ControlPlugParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// CreateVacation appends a createVacation function. See
+// Client.CreateVacation.
+func (b *FunctionBatch) CreateVacation(parameters *CreateVacationParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// DeleteVacation appends a deleteVacation function. See
+// Client.DeleteVacation.
+func (b *FunctionBatch) DeleteVacation(parameters *DeleteVacationParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// ResetPreferences appends a resetPreferences function. See
+// Client.ResetPreferences.
+func (b *FunctionBatch) ResetPreferences() *FunctionBatch {
+	return b.Function(objects.Function{
+		Type: String("resetPreferences"),
+	})
+}
+
+// ResumeProgram appends a resumeProgram function. See Client.ResumeProgram.
+func (b *FunctionBatch) ResumeProgram(parameters *ResumeProgramParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// SendMessage appends a sendMessage function. See Client.SendMessage.
+func (b *FunctionBatch) SendMessage(parameters *SendMessageParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// SetHold appends a setHold function. See Client.SetHold.
+func (b *FunctionBatch) SetHold(parameters *SetHoldParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// SetOccupied appends a setOccupied function. See Client.SetOccupied.
+func (b *FunctionBatch) SetOccupied(parameters *SetOccupiedParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// UnlinkVoiceEngine appends an unlinkVoiceEngine function. See
+// Client.UnlinkVoiceEngine.
+func (b *FunctionBatch) UnlinkVoiceEngine(parameters *UnlinkVoiceEngineParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// UpdateSensor appends an updateSensor function. See Client.UpdateSensor.
+func (b *FunctionBatch) UpdateSensor(parameters *UpdateSensorParameters) *FunctionBatch {
+	return b.appendFunction(parameters.validate, parameters.function)
+}
+
+// Functions returns the functions of the batch in the order they were
+// appended.
+func (b *FunctionBatch) Functions() []objects.Function {
+	return append([]objects.Function(nil), b.functions...)
+}
+
+// Err returns the *ValidationError of every function whose parameters are
+// missing or invalid, joined, or nil if every function is valid.
+func (b *FunctionBatch) Err() error {
+	return errors.Join(b.errs...)
+}
+
+// SendFunctionBatch sends the functions and the thermostat patch of batch in a
+// single UpdateThermostat request. ecobee applies the functions in order. No
+// request is sent if any function's parameters are invalid; see
+// FunctionBatch.Err.
+//
+// Unlike UpdateThermostat, SendFunctionBatch does not split a selection of
+// type thermostats matching more than 25 thermostats into separate requests,
+// as they would not be applied atomically. Such a selection fails with an
+// error matching ErrTooManyThermostats.
+func (c *Client) SendFunctionBatch(ctx context.Context, selection *objects.Selection, batch *FunctionBatch) (*APIStatusResponse, error) {
+	if err := batch.Err(); err != nil {
+		return nil, err
+	}
+
+	if selections := splitSelection(selection); selections != nil {
+		return nil, fmt.Errorf("%s: selection matches more than %d thermostats: %w", thermostatEndpoint, maxSelectionMatchThermostats, ErrTooManyThermostats)
+	}
+
+	return c.UpdateThermostat(ctx, selection, batch.thermostat, batch.Functions())
+}
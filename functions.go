@@ -18,6 +18,16 @@ const (
 	AckTypeUnacknowledged AckType = "unacknowledged"
 )
 
+// IsValid reports whether v is one of the supported AckType values.
+func (v AckType) IsValid() bool {
+	switch v {
+	case AckTypeAccept, AckTypeDecline, AckTypeDefer, AckTypeUnacknowledged:
+		return true
+	}
+
+	return false
+}
+
 // A FanMode specifies a fan mode.
 type FanMode string
 
@@ -27,6 +37,16 @@ const (
 	FanModeOn   FanMode = "on"
 )
 
+// IsValid reports whether v is one of the supported FanMode values.
+func (v FanMode) IsValid() bool {
+	switch v {
+	case FanModeAuto, FanModeOn:
+		return true
+	}
+
+	return false
+}
+
 // A HoldType specifies a hold type.
 type HoldType string
 
@@ -38,6 +58,16 @@ const (
 	HoldTypeNextTransition HoldType = "nextTransition"
 )
 
+// IsValid reports whether v is one of the supported HoldType values.
+func (v HoldType) IsValid() bool {
+	switch v {
+	case HoldTypeDateTime, HoldTypeHoldHours, HoldTypeIndefinite, HoldTypeNextTransition:
+		return true
+	}
+
+	return false
+}
+
 // A PlugState specifies a plug state.
 type PlugState string
 
@@ -48,6 +78,16 @@ const (
 	PlugStateResume PlugState = "resume"
 )
 
+// IsValid reports whether v is one of the supported PlugState values.
+func (v PlugState) IsValid() bool {
+	switch v {
+	case PlugStateOff, PlugStateOn, PlugStateResume:
+		return true
+	}
+
+	return false
+}
+
 // An AcknowledgeParameters specifies the request parameters of the Acknowledge
 // method.
 type AcknowledgeParameters struct {
@@ -65,22 +105,50 @@ type AcknowledgeParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/Acknowledge.shtml
 func (c *Client) Acknowledge(ctx context.Context, selection *objects.Selection, parameters *AcknowledgeParameters) (*APIStatusResponse, error) {
-	functions := []objects.Function{
-		{
-			Type: String("acknowledge"),
-			Params: map[string]interface{}{
-				"thermostatIdentifier": *parameters.ThermostatIdentifier,
-				"ackRef":               *parameters.AckRef,
-				"ackType":              *parameters.AckType,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *AcknowledgeParameters) function() objects.Function {
+	function := objects.Function{
+		Type: String("acknowledge"),
+		Params: map[string]interface{}{
+			"thermostatIdentifier": *p.ThermostatIdentifier,
+			"ackRef":               *p.AckRef,
+			"ackType":              *p.AckType,
 		},
 	}
 
-	if parameters.RemindMeLater != nil {
-		functions[0].Params["remindMeLater"] = *parameters.RemindMeLater
+	if p.RemindMeLater != nil {
+		function.Params["remindMeLater"] = *p.RemindMeLater
+	}
+
+	return function
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *AcknowledgeParameters) validate() error {
+	v := newValidator("acknowledge")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.required(p.ThermostatIdentifier != nil, "thermostatIdentifier")
+	v.required(p.AckRef != nil, "ackRef")
+	v.required(p.AckType != nil, "ackType")
+
+	if p.AckType != nil {
+		v.check(p.AckType.IsValid(), "ackType", "invalid value %q", *p.AckType)
 	}
 
-	return c.UpdateThermostat(ctx, selection, nil, functions)
+	return v.err()
 }
 
 // A ControlPlugParameters specifies the request parameters of the ControlPlug
@@ -104,39 +172,68 @@ type ControlPlugParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/ControlPlug.shtml
 func (c *Client) ControlPlug(ctx context.Context, selection *objects.Selection, parameters *ControlPlugParameters) (*APIStatusResponse, error) {
-	functions := []objects.Function{
-		{
-			Type: String("controlPlug"),
-			Params: map[string]interface{}{
-				"plugName":  *parameters.PlugName,
-				"plugState": *parameters.PlugState,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *ControlPlugParameters) function() objects.Function {
+	function := objects.Function{
+		Type: String("controlPlug"),
+		Params: map[string]interface{}{
+			"plugName":  *p.PlugName,
+			"plugState": *p.PlugState,
 		},
 	}
 
-	if parameters.StartDateTime != nil {
-		startDateTime := *parameters.StartDateTime
+	if p.StartDateTime != nil {
+		startDateTime := *p.StartDateTime
+
+		function.Params["startDate"] = startDateTime.Format("2006-01-02")
+		function.Params["startTime"] = startDateTime.Format("15:04:05")
+	}
+
+	if p.EndDateTime != nil {
+		endDateTime := *p.EndDateTime
 
-		functions[0].Params["startDate"] = startDateTime.Format("2006-01-02")
-		functions[0].Params["startTime"] = startDateTime.Format("15:04:05")
+		function.Params["endDate"] = endDateTime.Format("2006-01-02")
+		function.Params["endTime"] = endDateTime.Format("15:04:05")
 	}
 
-	if parameters.EndDateTime != nil {
-		endDateTime := *parameters.EndDateTime
+	if p.HoldType != nil {
+		function.Params["holdType"] = *p.HoldType
+	}
 
-		functions[0].Params["endDate"] = endDateTime.Format("2006-01-02")
-		functions[0].Params["endTime"] = endDateTime.Format("15:04:05")
+	if p.HoldHours != nil {
+		function.Params["holdHours"] = *p.HoldHours
 	}
 
-	if parameters.HoldType != nil {
-		functions[0].Params["holdType"] = *parameters.HoldType
+	return function
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *ControlPlugParameters) validate() error {
+	v := newValidator("controlPlug")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
 	}
 
-	if parameters.HoldHours != nil {
-		functions[0].Params["holdHours"] = *parameters.HoldHours
+	v.required(p.PlugName != nil, "plugName")
+	v.required(p.PlugState != nil, "plugState")
+
+	if p.PlugState != nil {
+		v.check(p.PlugState.IsValid(), "plugState", "invalid value %q", *p.PlugState)
 	}
 
-	return c.UpdateThermostat(ctx, selection, nil, functions)
+	v.hold(p.HoldType, p.HoldHours, p.StartDateTime, p.EndDateTime)
+
+	return v.err()
 }
 
 // A CreateVacationParameters specifies the request parameters of the
@@ -154,7 +251,8 @@ type CreateVacationParameters struct {
 	EndDateTime *time.Time
 	// The fan mode during the vacation.
 	Fan *FanMode
-	// The minimum number of minutes to run the fan each hour.
+	// The minimum number of minutes to run the fan each hour. ecobee documents
+	// it as a String holding a value from 0 to 60.
 	FanMinOnTime *string
 }
 
@@ -162,40 +260,71 @@ type CreateVacationParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/CreateVacation.shtml
 func (c *Client) CreateVacation(ctx context.Context, selection *objects.Selection, parameters *CreateVacationParameters) (*APIStatusResponse, error) {
-	functions := []objects.Function{
-		{
-			Type: String("createVacation"),
-			Params: map[string]interface{}{
-				"name":         *parameters.Name,
-				"coolHoldTemp": *parameters.CoolHoldTemp,
-				"heatHoldTemp": *parameters.HeatHoldTemp,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *CreateVacationParameters) function() objects.Function {
+	function := objects.Function{
+		Type: String("createVacation"),
+		Params: map[string]interface{}{
+			"name":         *p.Name,
+			"coolHoldTemp": *p.CoolHoldTemp,
+			"heatHoldTemp": *p.HeatHoldTemp,
 		},
 	}
 
-	if parameters.StartDateTime != nil {
-		startDateTime := *parameters.StartDateTime
+	if p.StartDateTime != nil {
+		startDateTime := *p.StartDateTime
+
+		function.Params["startDate"] = startDateTime.Format("2006-01-02")
+		function.Params["startTime"] = startDateTime.Format("15:04:05")
+	}
+
+	if p.EndDateTime != nil {
+		endDateTime := *p.EndDateTime
 
-		functions[0].Params["startDate"] = startDateTime.Format("2006-01-02")
-		functions[0].Params["startTime"] = startDateTime.Format("15:04:05")
+		function.Params["endDate"] = endDateTime.Format("2006-01-02")
+		function.Params["endTime"] = endDateTime.Format("15:04:05")
 	}
 
-	if parameters.EndDateTime != nil {
-		endDateTime := *parameters.EndDateTime
+	if p.Fan != nil {
+		function.Params["fan"] = *p.Fan
+	}
 
-		functions[0].Params["endDate"] = endDateTime.Format("2006-01-02")
-		functions[0].Params["endTime"] = endDateTime.Format("15:04:05")
+	if p.FanMinOnTime != nil {
+		function.Params["fanMinOnTime"] = *p.FanMinOnTime
 	}
 
-	if parameters.Fan != nil {
-		functions[0].Params["fan"] = *parameters.Fan
+	return function
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *CreateVacationParameters) validate() error {
+	v := newValidator("createVacation")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
 	}
 
-	if parameters.FanMinOnTime != nil {
-		functions[0].Params["fanMinOnTime"] = *parameters.FanMinOnTime
+	v.required(p.Name != nil, "name")
+	v.required(p.CoolHoldTemp != nil, "coolHoldTemp")
+	v.required(p.HeatHoldTemp != nil, "heatHoldTemp")
+
+	if p.Fan != nil {
+		v.check(p.Fan.IsValid(), "fan", "invalid value %q", *p.Fan)
 	}
 
-	return c.UpdateThermostat(ctx, selection, nil, functions)
+	v.numericStringRange(p.FanMinOnTime, "fanMinOnTime", 0, maxMinOnTime)
+	v.dateTimeRange(p.StartDateTime, p.EndDateTime)
+
+	return v.err()
 }
 
 // A DeleteVacationParameters specifies the request parameters of the
@@ -209,14 +338,36 @@ type DeleteVacationParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/DeleteVacation.shtml
 func (c *Client) DeleteVacation(ctx context.Context, selection *objects.Selection, parameters *DeleteVacationParameters) (*APIStatusResponse, error) {
-	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{
-		{
-			Type: String("deleteVacation"),
-			Params: map[string]interface{}{
-				"name": *parameters.Name,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *DeleteVacationParameters) function() objects.Function {
+	return objects.Function{
+		Type: String("deleteVacation"),
+		Params: map[string]interface{}{
+			"name": *p.Name,
 		},
-	})
+	}
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *DeleteVacationParameters) validate() error {
+	v := newValidator("deleteVacation")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.required(p.Name != nil, "name")
+
+	return v.err()
 }
 
 // ResetPreferences sets all of the user configurable settings back to the
@@ -244,14 +395,36 @@ type ResumeProgramParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/ResumeProgram.shtml
 func (c *Client) ResumeProgram(ctx context.Context, selection *objects.Selection, parameters *ResumeProgramParameters) (*APIStatusResponse, error) {
-	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{
-		{
-			Type: String("resumeProgram"),
-			Params: map[string]interface{}{
-				"resumeAll": *parameters.ResumeAll,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *ResumeProgramParameters) function() objects.Function {
+	return objects.Function{
+		Type: String("resumeProgram"),
+		Params: map[string]interface{}{
+			"resumeAll": *p.ResumeAll,
 		},
-	})
+	}
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *ResumeProgramParameters) validate() error {
+	v := newValidator("resumeProgram")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.required(p.ResumeAll != nil, "resumeAll")
+
+	return v.err()
 }
 
 // A SendMessageParameters specifies the request parameters of the
@@ -265,14 +438,36 @@ type SendMessageParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/SendMessage.shtml
 func (c *Client) SendMessage(ctx context.Context, selection *objects.Selection, parameters *SendMessageParameters) (*APIStatusResponse, error) {
-	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{
-		{
-			Type: String("sendMessage"),
-			Params: map[string]interface{}{
-				"text": *parameters.Text,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *SendMessageParameters) function() objects.Function {
+	return objects.Function{
+		Type: String("sendMessage"),
+		Params: map[string]interface{}{
+			"text": *p.Text,
 		},
-	})
+	}
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *SendMessageParameters) validate() error {
+	v := newValidator("sendMessage")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.required(p.Text != nil, "text")
+
+	return v.err()
 }
 
 // A SetHoldParameters specifies the request parameters of the
@@ -293,54 +488,286 @@ type SetHoldParameters struct {
 	HoldType *HoldType
 	// The number of hours to hold for.
 	HoldHours *int
+	// The fan mode during the hold.
+	Fan *FanMode
+	// The minimum number of minutes to run the fan each hour. Value from 0 to
+	// 60.
+	FanMinOnTime *int
+	// The ventilator mode during the hold.
+	Vent *objects.VentMode
+	// The minimum number of minutes to run the ventilator each hour. Value
+	// from 0 to 60.
+	VentilatorMinOnTime *int
+	// Whether there are persons occupying the property during the hold.
+	IsOccupied *bool
+	// Whether the cooling is turned off during the hold.
+	IsCoolOff *bool
+	// Whether the heating is turned off during the hold.
+	IsHeatOff *bool
+	// Whether the hold temperatures are absolute.
+	IsTemperatureAbsolute *bool
+	// Whether the hold temperatures are relative to the current program. If
+	// true, CoolRelativeTemp and HeatRelativeTemp are used.
+	IsTemperatureRelative *bool
+	// The relative cool temperature adjustment.
+	CoolRelativeTemp *int
+	// The relative heat temperature adjustment.
+	HeatRelativeTemp *int
+	// Whether the thermostat should use occupied sensors during the hold.
+	OccupiedSensorActive *bool
+	// Whether the thermostat should use unoccupied sensors during the hold.
+	UnoccupiedSensorActive *bool
+}
+
+// CoolHoldTempValue returns CoolHoldTemp as a Temperature, and whether it is
+// set.
+func (p *SetHoldParameters) CoolHoldTempValue() (objects.Temperature, bool) {
+	if p.CoolHoldTemp == nil {
+		return 0, false
+	}
+
+	return objects.Temperature(*p.CoolHoldTemp), true
+}
+
+// SetCoolHoldTempValue sets CoolHoldTemp to t.
+func (p *SetHoldParameters) SetCoolHoldTempValue(t objects.Temperature) {
+	p.CoolHoldTemp = Int(t.Tenths())
+}
+
+// HeatHoldTempValue returns HeatHoldTemp as a Temperature, and whether it is
+// set.
+func (p *SetHoldParameters) HeatHoldTempValue() (objects.Temperature, bool) {
+	if p.HeatHoldTemp == nil {
+		return 0, false
+	}
+
+	return objects.Temperature(*p.HeatHoldTemp), true
+}
+
+// SetHeatHoldTempValue sets HeatHoldTemp to t.
+func (p *SetHoldParameters) SetHeatHoldTempValue(t objects.Temperature) {
+	p.HeatHoldTemp = Int(t.Tenths())
 }
 
 // SetHold sets the thermostat into a hold with the specified temperature.
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/SetHold.shtml
 func (c *Client) SetHold(ctx context.Context, selection *objects.Selection, parameters *SetHoldParameters) (*APIStatusResponse, error) {
-	functions := []objects.Function{
-		{
-			Type:   String("setHold"),
-			Params: map[string]interface{}{},
-		},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *SetHoldParameters) function() objects.Function {
+	function := objects.Function{
+		Type:   String("setHold"),
+		Params: map[string]interface{}{},
+	}
+
+	if p.CoolHoldTemp != nil {
+		function.Params["coolHoldTemp"] = *p.CoolHoldTemp
+	}
+
+	if p.HeatHoldTemp != nil {
+		function.Params["heatHoldTemp"] = *p.HeatHoldTemp
+	}
+
+	if p.HoldClimateRef != nil {
+		function.Params["holdClimateRef"] = *p.HoldClimateRef
+	}
+
+	if p.StartDateTime != nil {
+		startDateTime := *p.StartDateTime
+
+		function.Params["startDate"] = startDateTime.Format("2006-01-02")
+		function.Params["startTime"] = startDateTime.Format("15:04:05")
+	}
+
+	if p.EndDateTime != nil {
+		endDateTime := *p.EndDateTime
+
+		function.Params["endDate"] = endDateTime.Format("2006-01-02")
+		function.Params["endTime"] = endDateTime.Format("15:04:05")
+	}
+
+	if p.HoldType != nil {
+		function.Params["holdType"] = *p.HoldType
+	}
+
+	if p.HoldHours != nil {
+		function.Params["holdHours"] = *p.HoldHours
+	}
+
+	if p.Fan != nil {
+		function.Params["fan"] = *p.Fan
+	}
+
+	if p.FanMinOnTime != nil {
+		function.Params["fanMinOnTime"] = *p.FanMinOnTime
+	}
+
+	if p.Vent != nil {
+		function.Params["vent"] = *p.Vent
 	}
 
-	if parameters.CoolHoldTemp != nil {
-		functions[0].Params["coolHoldTemp"] = *parameters.CoolHoldTemp
+	if p.VentilatorMinOnTime != nil {
+		function.Params["ventilatorMinOnTime"] = *p.VentilatorMinOnTime
 	}
 
-	if parameters.HeatHoldTemp != nil {
-		functions[0].Params["heatHoldTemp"] = *parameters.HeatHoldTemp
+	if p.IsOccupied != nil {
+		function.Params["isOccupied"] = *p.IsOccupied
 	}
 
-	if parameters.HoldClimateRef != nil {
-		functions[0].Params["holdClimateRef"] = *parameters.HoldClimateRef
+	if p.IsCoolOff != nil {
+		function.Params["isCoolOff"] = *p.IsCoolOff
 	}
 
-	if parameters.StartDateTime != nil {
-		startDateTime := *parameters.StartDateTime
+	if p.IsHeatOff != nil {
+		function.Params["isHeatOff"] = *p.IsHeatOff
+	}
+
+	if p.IsTemperatureAbsolute != nil {
+		function.Params["isTemperatureAbsolute"] = *p.IsTemperatureAbsolute
+	}
+
+	if p.IsTemperatureRelative != nil {
+		function.Params["isTemperatureRelative"] = *p.IsTemperatureRelative
+	}
+
+	if p.CoolRelativeTemp != nil {
+		function.Params["coolRelativeTemp"] = *p.CoolRelativeTemp
+	}
+
+	if p.HeatRelativeTemp != nil {
+		function.Params["heatRelativeTemp"] = *p.HeatRelativeTemp
+	}
+
+	if p.OccupiedSensorActive != nil {
+		function.Params["occupiedSensorActive"] = *p.OccupiedSensorActive
+	}
+
+	if p.UnoccupiedSensorActive != nil {
+		function.Params["unoccupiedSensorActive"] = *p.UnoccupiedSensorActive
+	}
+
+	return function
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *SetHoldParameters) validate() error {
+	v := newValidator("setHold")
+	if p == nil {
+		v.required(false, "parameters")
 
-		functions[0].Params["startDate"] = startDateTime.Format("2006-01-02")
-		functions[0].Params["startTime"] = startDateTime.Format("15:04:05")
+		return v.err()
 	}
 
-	if parameters.EndDateTime != nil {
-		endDateTime := *parameters.EndDateTime
+	relative := p.IsTemperatureRelative != nil && *p.IsTemperatureRelative
+
+	if p.HoldClimateRef == nil && !relative {
+		v.check(p.CoolHoldTemp != nil, "coolHoldTemp", "required when holdClimateRef is not set")
+		v.check(p.HeatHoldTemp != nil, "heatHoldTemp", "required when holdClimateRef is not set")
+	}
 
-		functions[0].Params["endDate"] = endDateTime.Format("2006-01-02")
-		functions[0].Params["endTime"] = endDateTime.Format("15:04:05")
+	if p.IsTemperatureAbsolute != nil && *p.IsTemperatureAbsolute {
+		v.check(!relative, "isTemperatureRelative", "mutually exclusive with isTemperatureAbsolute")
 	}
 
-	if parameters.HoldType != nil {
-		functions[0].Params["holdType"] = *parameters.HoldType
+	if p.Fan != nil {
+		v.check(p.Fan.IsValid(), "fan", "invalid value %q", *p.Fan)
 	}
 
-	if parameters.HoldHours != nil {
-		functions[0].Params["holdHours"] = *parameters.HoldHours
+	if p.Vent != nil {
+		v.check(p.Vent.IsValid(), "vent", "invalid value %q", *p.Vent)
 	}
 
-	return c.UpdateThermostat(ctx, selection, nil, functions)
+	v.intRange(p.FanMinOnTime, "fanMinOnTime", 0, maxMinOnTime)
+	v.intRange(p.VentilatorMinOnTime, "ventilatorMinOnTime", 0, maxMinOnTime)
+	v.hold(p.HoldType, p.HoldHours, p.StartDateTime, p.EndDateTime)
+
+	return v.err()
+}
+
+// A SetOccupiedParameters specifies the request parameters of the
+// SetOccupied method.
+type SetOccupiedParameters struct {
+	// Whether the thermostat should be set to occupied (true) or unoccupied
+	// (false).
+	Occupied *bool
+	// The start date & time in thermostat time.
+	StartDateTime *time.Time
+	// The end date & time in thermostat time.
+	EndDateTime *time.Time
+	// The hold duration type.
+	HoldType *HoldType
+	// The number of hours to hold for.
+	HoldHours *int
+}
+
+// SetOccupied switches a thermostat from occupied mode to unoccupied mode and
+// vice versa. It is only available to EMS thermostats.
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/SetOccupied.shtml
+func (c *Client) SetOccupied(ctx context.Context, selection *objects.Selection, parameters *SetOccupiedParameters) (*APIStatusResponse, error) {
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *SetOccupiedParameters) function() objects.Function {
+	function := objects.Function{
+		Type: String("setOccupied"),
+		Params: map[string]interface{}{
+			"occupied": *p.Occupied,
+		},
+	}
+
+	if p.StartDateTime != nil {
+		startDateTime := *p.StartDateTime
+
+		function.Params["startDate"] = startDateTime.Format("2006-01-02")
+		function.Params["startTime"] = startDateTime.Format("15:04:05")
+	}
+
+	if p.EndDateTime != nil {
+		endDateTime := *p.EndDateTime
+
+		function.Params["endDate"] = endDateTime.Format("2006-01-02")
+		function.Params["endTime"] = endDateTime.Format("15:04:05")
+	}
+
+	if p.HoldType != nil {
+		function.Params["holdType"] = *p.HoldType
+	}
+
+	if p.HoldHours != nil {
+		function.Params["holdHours"] = *p.HoldHours
+	}
+
+	return function
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *SetOccupiedParameters) validate() error {
+	v := newValidator("setOccupied")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.required(p.Occupied != nil, "occupied")
+	v.hold(p.HoldType, p.HoldHours, p.StartDateTime, p.EndDateTime)
+
+	return v.err()
 }
 
 // An UnlinkVoiceEngineParameters specifies the request parameters of the
@@ -354,14 +781,36 @@ type UnlinkVoiceEngineParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/UnlinkVoiceEngine.shtml
 func (c *Client) UnlinkVoiceEngine(ctx context.Context, selection *objects.Selection, parameters *UnlinkVoiceEngineParameters) (*APIStatusResponse, error) {
-	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{
-		{
-			Type: String("unlinkVoiceEngine"),
-			Params: map[string]interface{}{
-				"engineName": *parameters.EngineName,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *UnlinkVoiceEngineParameters) function() objects.Function {
+	return objects.Function{
+		Type: String("unlinkVoiceEngine"),
+		Params: map[string]interface{}{
+			"engineName": *p.EngineName,
 		},
-	})
+	}
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *UnlinkVoiceEngineParameters) validate() error {
+	v := newValidator("unlinkVoiceEngine")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.required(p.EngineName != nil, "engineName")
+
+	return v.err()
 }
 
 // An UpdateSensorParameters specifies the request parameters of the UpdateSensor
@@ -379,14 +828,38 @@ type UpdateSensorParameters struct {
 //
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/functions/UpdateSensor.shtml
 func (c *Client) UpdateSensor(ctx context.Context, selection *objects.Selection, parameters *UpdateSensorParameters) (*APIStatusResponse, error) {
-	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{
-		{
-			Type: String("updateSensor"),
-			Params: map[string]interface{}{
-				"name":     *parameters.Name,
-				"deviceId": *parameters.DeviceID,
-				"sensorId": *parameters.SensorID,
-			},
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.UpdateThermostat(ctx, selection, nil, []objects.Function{parameters.function()})
+}
+
+// function returns the Function corresponding to p.
+func (p *UpdateSensorParameters) function() objects.Function {
+	return objects.Function{
+		Type: String("updateSensor"),
+		Params: map[string]interface{}{
+			"name":     *p.Name,
+			"deviceId": *p.DeviceID,
+			"sensorId": *p.SensorID,
 		},
-	})
+	}
+}
+
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *UpdateSensorParameters) validate() error {
+	v := newValidator("updateSensor")
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.required(p.Name != nil, "name")
+	v.required(p.DeviceID != nil, "deviceId")
+	v.required(p.SensorID != nil, "sensorId")
+
+	return v.err()
 }
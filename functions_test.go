@@ -0,0 +1,100 @@
+package ecobee
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+func TestFunctionJSON(t *testing.T) {
+	startDateTime := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	endDateTime := time.Date(2024, time.January, 9, 22, 30, 0, 0, time.UTC)
+	fanModeOn := FanModeOn
+	holdTypeHoldHours := HoldTypeHoldHours
+	holdTypeDateTime := HoldTypeDateTime
+	ventModeMinOnTime := objects.VentModeMinOnTime
+
+	tests := []struct {
+		name     string
+		function objects.Function
+		want     string
+	}{
+		{
+			name: "setHold fan vent and occupancy",
+			function: (&SetHoldParameters{
+				HoldClimateRef:      String("home"),
+				Fan:                 &fanModeOn,
+				FanMinOnTime:        Int(15),
+				Vent:                &ventModeMinOnTime,
+				VentilatorMinOnTime: Int(20),
+				IsOccupied:          Bool(true),
+			}).function(),
+			want: `{"type":"setHold","params":{"fan":"on","fanMinOnTime":15,"holdClimateRef":"home","isOccupied":true,"vent":"minontime","ventilatorMinOnTime":20}}`,
+		},
+		{
+			name: "setHold hold hours",
+			function: (&SetHoldParameters{
+				CoolHoldTemp: Int(780),
+				HeatHoldTemp: Int(680),
+				HoldType:     &holdTypeHoldHours,
+				HoldHours:    Int(2),
+			}).function(),
+			want: `{"type":"setHold","params":{"coolHoldTemp":780,"heatHoldTemp":680,"holdHours":2,"holdType":"holdHours"}}`,
+		},
+		{
+			name: "setHold date time",
+			function: (&SetHoldParameters{
+				HoldClimateRef: String("away"),
+				StartDateTime:  &startDateTime,
+				EndDateTime:    &endDateTime,
+				HoldType:       &holdTypeDateTime,
+			}).function(),
+			want: `{"type":"setHold","params":{"endDate":"2024-01-09","endTime":"22:30:00","holdClimateRef":"away","holdType":"dateTime","startDate":"2024-01-02","startTime":"03:04:05"}}`,
+		},
+		{
+			name: "setOccupied",
+			function: (&SetOccupiedParameters{
+				Occupied: Bool(false),
+			}).function(),
+			want: `{"type":"setOccupied","params":{"occupied":false}}`,
+		},
+		{
+			name: "setOccupied hold hours",
+			function: (&SetOccupiedParameters{
+				Occupied:      Bool(true),
+				StartDateTime: &startDateTime,
+				HoldType:      &holdTypeHoldHours,
+				HoldHours:     Int(4),
+			}).function(),
+			want: `{"type":"setOccupied","params":{"holdHours":4,"holdType":"holdHours","occupied":true,"startDate":"2024-01-02","startTime":"03:04:05"}}`,
+		},
+		{
+			name: "createVacation",
+			function: (&CreateVacationParameters{
+				Name:          String("Skiing"),
+				CoolHoldTemp:  Int(850),
+				HeatHoldTemp:  Int(550),
+				StartDateTime: &startDateTime,
+				EndDateTime:   &endDateTime,
+				Fan:           &fanModeOn,
+				FanMinOnTime:  String("10"),
+			}).function(),
+			want: `{"type":"createVacation","params":{"coolHoldTemp":850,"endDate":"2024-01-09","endTime":"22:30:00","fan":"on","fanMinOnTime":"10","heatHoldTemp":550,"name":"Skiing","startDate":"2024-01-02","startTime":"03:04:05"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.function)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			if got := string(data); got != test.want {
+				t.Errorf("json.Marshal() =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
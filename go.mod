@@ -6,5 +6,3 @@ require (
 	golang.org/x/net v0.57.0
 	golang.org/x/oauth2 v0.36.0
 )
-
-require github.com/google/go-cmp v0.6.0 // indirect
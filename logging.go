@@ -0,0 +1,138 @@
+package ecobee
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// redacted replaces secrets such as tokens, PINs and authorization codes in
+// strings produced by this package.
+const redacted = "REDACTED"
+
+// redactedQueryParameters lists the query parameters carrying secrets.
+var redactedQueryParameters = []string{"code", "access_token", "refresh_token"}
+
+// WithLogger returns a function that initializes a Client with a structured
+// logger. Every attempt of a request is logged once its response is received
+// with the following attributes:
+//
+//   - endpoint: the name of the API endpoint (e.g. thermostat).
+//   - method: the HTTP method.
+//   - attempt: the attempt number, greater than 1 for retries.
+//   - duration: the time spent waiting for the response.
+//   - httpStatus: the HTTP status code.
+//   - statusCode: the ecobee status code, if the response carries one.
+//   - thermostatCount: the number of thermostats returned, if any.
+//   - page and totalPages: the page returned, if any.
+//
+// Successful attempts are logged at the Debug level and failed attempts at
+// the Warn level. Secrets such as access tokens, refresh tokens, PINs and
+// authorization codes are never logged.
+func WithLogger(logger *slog.Logger) func(*Client) {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+func loggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *APIRequest) (*http.Response, error) {
+			start := time.Now()
+
+			resp, err := next(ctx, req)
+
+			attrs := []slog.Attr{
+				slog.String("endpoint", req.Endpoint),
+				slog.String("method", req.HTTPRequest.Method),
+				slog.Int("attempt", req.Attempt),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+
+				logger.LogAttrs(ctx, slog.LevelWarn, "ecobee API request failed", attrs...)
+
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("httpStatus", resp.StatusCode))
+
+			if summary := summarizeResponse(resp); summary != nil {
+				attrs = append(attrs, summary.attrs()...)
+			}
+
+			level := slog.LevelDebug
+			if resp.StatusCode != http.StatusOK {
+				level = slog.LevelWarn
+			}
+
+			logger.LogAttrs(ctx, level, "ecobee API request", attrs...)
+
+			return resp, err
+		}
+	}
+}
+
+// attrs returns the logged attributes of the summary.
+func (r *responseSummary) attrs() []slog.Attr {
+	attrs := []slog.Attr{}
+
+	if r.Status != nil && r.Status.Code != nil {
+		attrs = append(attrs, slog.Int("statusCode", *r.Status.Code))
+	}
+
+	switch {
+	case r.ThermostatCount != nil:
+		attrs = append(attrs, slog.Int("thermostatCount", *r.ThermostatCount))
+	case r.ThermostatList != nil:
+		attrs = append(attrs, slog.Int("thermostatCount", len(r.ThermostatList)))
+	}
+
+	if r.Page != nil {
+		if r.Page.Page != nil {
+			attrs = append(attrs, slog.Int("page", *r.Page.Page))
+		}
+
+		if r.Page.TotalPages != nil {
+			attrs = append(attrs, slog.Int("totalPages", *r.Page.TotalPages))
+		}
+	}
+
+	return attrs
+}
+
+// redactURL returns u as a string with the values of the query parameters
+// carrying secrets replaced.
+func redactURL(u *url.URL) string {
+	queryParameters := u.Query()
+	redact := false
+
+	for _, queryParameter := range redactedQueryParameters {
+		if queryParameters.Has(queryParameter) {
+			queryParameters.Set(queryParameter, redacted)
+			redact = true
+		}
+	}
+
+	if !redact {
+		return u.String()
+	}
+
+	redactedURL := *u
+	redactedURL.RawQuery = queryParameters.Encode()
+
+	return redactedURL.String()
+}
+
+// redactString returns redacted unless s is empty.
+func redactString(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return redacted
+}
@@ -0,0 +1,243 @@
+package ecobee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+	"golang.org/x/oauth2"
+)
+
+// An APIRequest describes a request sent by a Client through its middleware
+// chain.
+type APIRequest struct {
+	// The name of the API endpoint (e.g. thermostat, group, runtimeReport).
+	Endpoint string
+	// The selection of the request, or nil if the endpoint does not take one.
+	// Middleware must treat it as read-only; it has already been encoded into
+	// HTTPRequest.
+	Selection *objects.Selection
+	// The attempt number of the request, starting at 1. It is greater than 1
+	// when the request is retried according to the Client's RetryPolicy.
+	Attempt int
+	// The HTTP request to send. Middleware may modify its headers or replace
+	// it.
+	HTTPRequest *http.Request
+}
+
+// A RoundTripFunc sends an APIRequest and returns its HTTP response.
+type RoundTripFunc func(ctx context.Context, req *APIRequest) (*http.Response, error)
+
+// A Middleware wraps a RoundTripFunc to observe or alter requests and
+// responses.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware returns a function that initializes a Client with one or more
+// middleware. Middleware is applied in the order it is passed: the first
+// middleware is the outermost and sees the request first and the response
+// last. Calling WithMiddleware more than once appends to the chain.
+//
+// The chain wraps every attempt of a request, so each retry made according to
+// the Client's RetryPolicy goes through the whole chain again. Requests are
+// only passed to the chain once the Client's rate limits allow them.
+//
+// The chain runs before the request reaches the Client's HTTP client. If the
+// Client authorizes requests through an oauth2 transport, e.g. because it is
+// initialized with WithToken, the transport sets the Authorization header
+// after the chain has run. A request leaving the chain with an Authorization
+// header therefore fails with an error matching ErrAuthorizationHeader
+// instead of being sent with a different header than the middleware set. To
+// authorize requests from a middleware, initialize the Client with
+// WithHTTPClient and without WithToken.
+//
+// The below example logs every request:
+//
+//	logging := func(next ecobee.RoundTripFunc) ecobee.RoundTripFunc {
+//		return func(ctx context.Context, req *ecobee.APIRequest) (*http.Response, error) {
+//			start := time.Now()
+//
+//			resp, err := next(ctx, req)
+//			if err != nil {
+//				log.Printf("%s: attempt %d: %v", req.Endpoint, req.Attempt, err)
+//
+//				return resp, err
+//			}
+//
+//			log.Printf("%s: attempt %d: %s in %s", req.Endpoint, req.Attempt, resp.Status, time.Since(start))
+//
+//			return resp, err
+//		}
+//	}
+//
+//	client := ecobee.NewClient(ecobee.WithMiddleware(logging))
+func WithMiddleware(middleware ...Middleware) func(*Client) {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// An APICall describes a call made by a Client to an API endpoint, spanning
+// every attempt of its request.
+type APICall struct {
+	// The name of the API endpoint (e.g. thermostat, group, runtimeReport).
+	Endpoint string
+	// The selection of the call, or nil if the endpoint does not take one.
+	// Middleware must treat it as read-only.
+	Selection *objects.Selection
+	// The HTTP method of the request.
+	Method string
+	// The number of attempts made so far. Once the call returns it is the
+	// total number of attempts, or 0 if the call failed before the first
+	// attempt was sent.
+	Attempts int
+}
+
+// A CallFunc makes an APICall and returns the HTTP response of its last
+// attempt.
+type CallFunc func(ctx context.Context, call *APICall) (*http.Response, error)
+
+// A CallMiddleware wraps a CallFunc to observe or alter whole calls.
+type CallMiddleware func(next CallFunc) CallFunc
+
+// WithCallMiddleware returns a function that initializes a Client with one or
+// more call middleware. Unlike a Middleware, which wraps every attempt of a
+// request, a CallMiddleware wraps a call once: it sees the call before it is
+// rate limited and sent, and the response of the last attempt after every
+// retry made according to the Client's RetryPolicy. The context it passes to
+// next is the context seen by the Middleware chain of every attempt.
+//
+// Call middleware is applied in the order it is passed: the first call
+// middleware is the outermost. Calling WithCallMiddleware more than once
+// appends to the chain.
+func WithCallMiddleware(callMiddleware ...CallMiddleware) func(*Client) {
+	return func(c *Client) {
+		c.callMiddleware = append(c.callMiddleware, callMiddleware...)
+	}
+}
+
+// ResponseStatus returns the ecobee status carried in the body of resp, or nil
+// if the body does not contain one. The body is buffered so it can still be
+// read afterwards, and decoded once however many times ResponseStatus and
+// ResponsePage are called. It is intended to be used by Middleware.
+func ResponseStatus(resp *http.Response) *objects.Status {
+	summary := summarizeResponse(resp)
+	if summary == nil {
+		return nil
+	}
+
+	return summary.Status
+}
+
+// ResponsePage returns the page carried in the body of resp, or nil if the body
+// does not contain one. The body is buffered so it can still be read
+// afterwards, and decoded once however many times ResponseStatus and
+// ResponsePage are called. It is intended to be used by Middleware.
+func ResponsePage(resp *http.Response) *objects.Page {
+	summary := summarizeResponse(resp)
+	if summary == nil {
+		return nil
+	}
+
+	return summary.Page
+}
+
+// A responseSummary holds the parts of a response inspected by the middleware
+// chain and the retry policy.
+type responseSummary struct {
+	Status          *objects.Status   `json:"status,omitempty"`
+	Page            *objects.Page     `json:"page,omitempty"`
+	ThermostatList  []json.RawMessage `json:"thermostatList,omitempty"`
+	ThermostatCount *int              `json:"thermostatCount,omitempty"`
+}
+
+// A responseBody is the buffered body of a response. Its summary is decoded on
+// first use and cached, so inspecting a response in several middleware does
+// not decode it more than once.
+type responseBody struct {
+	*bytes.Reader
+	data    []byte
+	decoded bool
+	summary *responseSummary
+}
+
+// Close implements the io.Closer interface.
+func (b *responseBody) Close() error {
+	return nil
+}
+
+// bufferResponse reads the body of resp and replaces it with a responseBody.
+func bufferResponse(resp *http.Response) error {
+	data, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	resp.Body = &responseBody{
+		Reader: bytes.NewReader(data),
+		data:   data,
+	}
+
+	return err
+}
+
+// summarizeResponse returns the summary of resp, or nil if its body is not a
+// JSON object. A body replaced by a middleware is buffered again.
+func summarizeResponse(resp *http.Response) *responseSummary {
+	body, ok := resp.Body.(*responseBody)
+	if !ok {
+		if err := bufferResponse(resp); err != nil {
+			return nil
+		}
+
+		body = resp.Body.(*responseBody)
+	}
+
+	if !body.decoded {
+		body.decoded = true
+
+		summary := responseSummary{}
+		if err := json.Unmarshal(body.data, &summary); err == nil {
+			body.summary = &summary
+		}
+	}
+
+	return body.summary
+}
+
+func (c *Client) roundTrip() RoundTripFunc {
+	roundTrip := func(ctx context.Context, req *APIRequest) (*http.Response, error) {
+		if _, ok := c.httpClient.Transport.(*oauth2.Transport); ok && req.HTTPRequest.Header.Get("Authorization") != "" {
+			return nil, ErrAuthorizationHeader
+		}
+
+		resp, err := c.doRequest(ctx, req.HTTPRequest)
+		if err != nil {
+			return resp, err
+		}
+
+		if err := bufferResponse(resp); err != nil {
+			return nil, err
+		}
+
+		return resp, nil
+	}
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		roundTrip = c.middleware[i](roundTrip)
+	}
+
+	if c.logger != nil {
+		roundTrip = loggingMiddleware(c.logger)(roundTrip)
+	}
+
+	return roundTrip
+}
+
+func (c *Client) callChain(call CallFunc) CallFunc {
+	for i := len(c.callMiddleware) - 1; i >= 0; i-- {
+		call = c.callMiddleware[i](call)
+	}
+
+	return call
+}
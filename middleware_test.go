@@ -0,0 +1,160 @@
+package ecobee
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+	"golang.org/x/oauth2"
+)
+
+func TestResponseSummaryDecodedOnce(t *testing.T) {
+	statuses := []*objects.Status{}
+
+	inspecting := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *APIRequest) (*http.Response, error) {
+			resp, err := next(ctx, req)
+			if err != nil {
+				return resp, err
+			}
+
+			if ResponsePage(resp) == nil {
+				t.Error("ResponsePage() = nil, want the page")
+			}
+
+			statuses = append(statuses, ResponseStatus(resp), ResponseStatus(resp))
+
+			return resp, err
+		}
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(thermostatResponseBody))
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}))), WithMiddleware(inspecting))
+
+	thermostatResponse, err := client.Thermostat(context.Background(), &objects.Selection{SelectionType: String("registered")}, nil)
+	if err != nil {
+		t.Fatalf("Thermostat() error = %v", err)
+	}
+
+	if len(thermostatResponse.ThermostatList()) != 1 {
+		t.Errorf("len(ThermostatList()) = %d, want 1", len(thermostatResponse.ThermostatList()))
+	}
+
+	if statuses[0] == nil || statuses[0] != statuses[1] {
+		t.Errorf("ResponseStatus() = %p, %p, want the same cached status", statuses[0], statuses[1])
+	}
+}
+
+func TestMiddlewareAuthorizationHeader(t *testing.T) {
+	authorizing := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *APIRequest) (*http.Response, error) {
+			req.HTTPRequest.Header.Set("Authorization", "Bearer middleware")
+
+			return next(ctx, req)
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		optionalParameters    []clientOptionalParameters
+		wantErr               error
+		wantAuthorization     string
+		wantThermostatRequest bool
+	}{
+		{
+			name:                  "without oauth2 transport the header is sent",
+			wantAuthorization:     "Bearer middleware",
+			wantThermostatRequest: true,
+		},
+		{
+			name: "with oauth2 transport the request is rejected",
+			optionalParameters: []clientOptionalParameters{
+				WithToken("applicationKey", &oauth2.Token{AccessToken: "accessToken", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}),
+			},
+			wantErr: ErrAuthorizationHeader,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authorization, thermostatRequest := "", false
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				authorization, thermostatRequest = r.Header.Get("Authorization"), true
+
+				_, _ = w.Write([]byte(thermostatResponseBody))
+			}, append(test.optionalParameters, WithMiddleware(authorizing))...)
+
+			_, err := client.Thermostat(context.Background(), &objects.Selection{SelectionType: String("registered")}, nil)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Thermostat() error = %v, want %v", err, test.wantErr)
+			}
+
+			if thermostatRequest != test.wantThermostatRequest {
+				t.Errorf("request sent = %t, want %t", thermostatRequest, test.wantThermostatRequest)
+			}
+
+			if authorization != test.wantAuthorization {
+				t.Errorf("Authorization = %q, want %q", authorization, test.wantAuthorization)
+			}
+		})
+	}
+}
+
+func TestCallMiddleware(t *testing.T) {
+	type contextKey struct{}
+
+	calls, attempts := []APICall{}, []int{}
+
+	calling := func(next CallFunc) CallFunc {
+		return func(ctx context.Context, call *APICall) (*http.Response, error) {
+			resp, err := next(context.WithValue(ctx, contextKey{}, true), call)
+
+			calls = append(calls, *call)
+
+			return resp, err
+		}
+	}
+
+	attempting := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *APIRequest) (*http.Response, error) {
+			if ctx.Value(contextKey{}) == nil {
+				t.Error("attempt context does not derive from the call context")
+			}
+
+			attempts = append(attempts, req.Attempt)
+
+			return next(ctx, req)
+		}
+	}
+
+	requests := 0
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests++; requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(thermostatResponseBody))
+	}, WithRetryPolicy(testRetryPolicy()), WithCallMiddleware(calling), WithMiddleware(attempting))
+
+	if _, err := client.Thermostat(context.Background(), &objects.Selection{SelectionType: String("registered")}, nil); err != nil {
+		t.Fatalf("Thermostat() error = %v", err)
+	}
+
+	if len(calls) != 1 || calls[0].Endpoint != thermostatEndpoint || calls[0].Method != http.MethodGet || calls[0].Attempts != 2 {
+		t.Errorf("calls = %+v, want 1 GET thermostat call with 2 attempts", calls)
+	}
+
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("attempts = %v, want [1 2]", attempts)
+	}
+}
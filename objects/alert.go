@@ -1,5 +1,7 @@
 package objects
 
+import "time"
+
 // The Alert object represents an alert generated either by a thermostat or user
 // which requires user attention. It may be an error, or a reminder for a filter
 // change. Alerts may not be modified directly but rather they must be
@@ -15,7 +17,7 @@ type Alert struct {
 	Time *string `json:"time,omitempty"`
 
 	// The alert severity. Values: low, medium, high.
-	Severity *string `json:"severity,omitempty"`
+	Severity *AlertSeverity `json:"severity,omitempty"`
 
 	// The alert message text. Will be truncated to 500 characters if longer.
 	Text *string `json:"text,omitempty"`
@@ -58,3 +60,29 @@ type Alert struct {
 	// alert type is required, see NotificationSettings
 	NotificationType *string `json:"notificationType,omitempty"`
 }
+
+// DateTime returns Date and Time parsed in location, the thermostat's time
+// zone. See Thermostat.TimeLocation.
+func (a *Alert) DateTime(location *time.Location) (time.Time, error) {
+	return parseDateAndTime("date", a.Date, "time", a.Time, location)
+}
+
+// An AlertSeverity specifies the severity of an Alert.
+type AlertSeverity string
+
+// Supported AlertSeverity values.
+const (
+	AlertSeverityLow    AlertSeverity = "low"
+	AlertSeverityMedium AlertSeverity = "medium"
+	AlertSeverityHigh   AlertSeverity = "high"
+)
+
+// IsValid reports whether v is one of the supported AlertSeverity values.
+func (v AlertSeverity) IsValid() bool {
+	switch v {
+	case AlertSeverityLow, AlertSeverityMedium, AlertSeverityHigh:
+		return true
+	}
+
+	return false
+}
@@ -36,14 +36,14 @@ type Climate struct {
 	HeatFan *string `json:"heatFan,omitempty"`
 
 	// The ventilator mode. Default: off. Values: auto, minontime, on, off.
-	Vent *string `json:"vent,omitempty"`
+	Vent *VentMode `json:"vent,omitempty"`
 
 	// The minimum time, in minutes, to run the ventilator each hour.
 	VentilatorMinOnTime *int `json:"ventilatorMinOnTime,omitempty"`
 
 	// The climate owner. Default: system. Values: adHoc, demandResponse,
 	// quickSave, sensorAction, switchOccupancy, system, template, user.
-	Owner *string `json:"owner,omitempty"`
+	Owner *ClimateOwner `json:"owner,omitempty"`
 
 	// The type of climate. Default: program. Values: calendarEvent, program.
 	Type *string `json:"type,omitempty"`
@@ -63,3 +63,38 @@ type Climate struct {
 	// and name are listed in the climate.
 	Sensors []RemoteSensor `json:"sensors,omitempty"`
 }
+
+// CoolTempValue returns CoolTemp as a Temperature, and whether it is set.
+func (c *Climate) CoolTempValue() (Temperature, bool) {
+	return temperature(c.CoolTemp)
+}
+
+// HeatTempValue returns HeatTemp as a Temperature, and whether it is set.
+func (c *Climate) HeatTempValue() (Temperature, bool) {
+	return temperature(c.HeatTemp)
+}
+
+// A ClimateOwner specifies the owner of a Climate.
+type ClimateOwner string
+
+// Supported ClimateOwner values.
+const (
+	ClimateOwnerAdHoc           ClimateOwner = "adHoc"
+	ClimateOwnerDemandResponse  ClimateOwner = "demandResponse"
+	ClimateOwnerQuickSave       ClimateOwner = "quickSave"
+	ClimateOwnerSensorAction    ClimateOwner = "sensorAction"
+	ClimateOwnerSwitchOccupancy ClimateOwner = "switchOccupancy"
+	ClimateOwnerSystem          ClimateOwner = "system"
+	ClimateOwnerTemplate        ClimateOwner = "template"
+	ClimateOwnerUser            ClimateOwner = "user"
+)
+
+// IsValid reports whether v is one of the supported ClimateOwner values.
+func (v ClimateOwner) IsValid() bool {
+	switch v {
+	case ClimateOwnerAdHoc, ClimateOwnerDemandResponse, ClimateOwnerQuickSave, ClimateOwnerSensorAction, ClimateOwnerSwitchOccupancy, ClimateOwnerSystem, ClimateOwnerTemplate, ClimateOwnerUser:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package objects
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Layouts of the date and time strings returned by ecobee.
+const (
+	dateLayout     = "2006-01-02"
+	timeLayout     = "15:04:05"
+	dateTimeLayout = dateLayout + " " + timeLayout
+)
+
+// ErrNoTimeZone is returned when a thermostat local date or time is parsed and
+// the thermostat's time zone is unknown. Include the Location object in the
+// selection to retrieve it.
+var ErrNoTimeZone = errors.New("thermostat time zone unknown")
+
+// ErrFieldNotSet is returned when a date or time is parsed from a field that
+// is not set.
+var ErrFieldNotSet = errors.New("field not set")
+
+// TimeLocation returns the thermostat's time zone. It is loaded from TimeZone
+// if set. If TimeZone is not set or cannot be loaded (e.g. the time zone
+// database is unavailable), it is a fixed zone offset from UTC by
+// TimeZoneOffsetMinutes, which does not follow daylight saving time.
+func (l *Location) TimeLocation() (*time.Location, error) {
+	if l == nil {
+		return nil, ErrNoTimeZone
+	}
+
+	var loadErr error
+
+	if l.TimeZone != nil && *l.TimeZone != "" {
+		location, err := time.LoadLocation(*l.TimeZone)
+		if err == nil {
+			return location, nil
+		}
+
+		loadErr = fmt.Errorf("timeZone %q: %w", *l.TimeZone, err)
+	}
+
+	if l.TimeZoneOffsetMinutes != nil {
+		name := ""
+		if l.TimeZone != nil {
+			name = *l.TimeZone
+		}
+
+		return time.FixedZone(name, *l.TimeZoneOffsetMinutes*60), nil
+	}
+
+	if loadErr != nil {
+		return nil, loadErr
+	}
+
+	return nil, ErrNoTimeZone
+}
+
+// parseTime parses the value of the named field using layout in location.
+func parseTime(field string, layout string, value *string, location *time.Location) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, fmt.Errorf("%s: %w", field, ErrFieldNotSet)
+	}
+
+	if location == nil {
+		return time.Time{}, fmt.Errorf("%s: %w", field, ErrNoTimeZone)
+	}
+
+	t, err := time.ParseInLocation(layout, *value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: malformed value %q: %w", field, *value, err)
+	}
+
+	return t, nil
+}
+
+// parseDateAndTime parses the values of a pair of date and time fields in
+// location.
+func parseDateAndTime(dateField string, date *string, timeField string, clock *string, location *time.Location) (time.Time, error) {
+	if date == nil {
+		return time.Time{}, fmt.Errorf("%s: %w", dateField, ErrFieldNotSet)
+	}
+
+	if clock == nil {
+		return time.Time{}, fmt.Errorf("%s: %w", timeField, ErrFieldNotSet)
+	}
+
+	value := *date + " " + *clock
+
+	return parseTime(dateField+"/"+timeField, dateTimeLayout, &value, location)
+}
@@ -0,0 +1,207 @@
+package objects
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func pointer[T any](v T) *T {
+	return &v
+}
+
+func TestLocationTimeLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		location   *Location
+		wantOffset int
+		wantErr    error
+	}{
+		{
+			name:    "nil location",
+			wantErr: ErrNoTimeZone,
+		},
+		{
+			name:     "no time zone",
+			location: &Location{},
+			wantErr:  ErrNoTimeZone,
+		},
+		{
+			name:       "time zone",
+			location:   &Location{TimeZone: pointer("UTC"), TimeZoneOffsetMinutes: pointer(-300)},
+			wantOffset: 0,
+		},
+		{
+			name:       "offset only",
+			location:   &Location{TimeZoneOffsetMinutes: pointer(-300)},
+			wantOffset: -300 * 60,
+		},
+		{
+			name:       "unknown time zone falls back to the offset",
+			location:   &Location{TimeZone: pointer("Nowhere/Unknown"), TimeZoneOffsetMinutes: pointer(330)},
+			wantOffset: 330 * 60,
+		},
+		{
+			name:     "unknown time zone without offset",
+			location: &Location{TimeZone: pointer("Nowhere/Unknown")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location, err := test.location.TimeLocation()
+
+			if test.wantErr != nil || location == nil {
+				if err == nil || (test.wantErr != nil && !errors.Is(err, test.wantErr)) {
+					t.Fatalf("TimeLocation() = %v, %v, want error %v", location, err, test.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("TimeLocation() error = %v", err)
+			}
+
+			if _, offset := time.Date(2024, time.January, 15, 12, 0, 0, 0, location).Zone(); offset != test.wantOffset {
+				t.Errorf("TimeLocation() offset = %d, want %d", offset, test.wantOffset)
+			}
+		})
+	}
+}
+
+func TestThermostatTimeValues(t *testing.T) {
+	thermostat := &Thermostat{
+		ThermostatTime: pointer("2024-01-15 07:30:00"),
+		UTCTime:        pointer("2024-01-15 12:30:00"),
+		Location:       &Location{TimeZoneOffsetMinutes: pointer(-300)},
+	}
+
+	thermostatTime, err := thermostat.ThermostatTimeValue()
+	if err != nil {
+		t.Fatalf("ThermostatTimeValue() error = %v", err)
+	}
+
+	utcTime, err := thermostat.UTCTimeValue()
+	if err != nil {
+		t.Fatalf("UTCTimeValue() error = %v", err)
+	}
+
+	if !thermostatTime.Equal(utcTime) {
+		t.Errorf("ThermostatTimeValue() = %s, want %s", thermostatTime, utcTime)
+	}
+
+	if _, err := (&Thermostat{ThermostatTime: pointer("2024-01-15 07:30:00")}).ThermostatTimeValue(); !errors.Is(err, ErrNoTimeZone) {
+		t.Errorf("ThermostatTimeValue() error = %v, want %v", err, ErrNoTimeZone)
+	}
+}
+
+func TestParsedTimeAccessors(t *testing.T) {
+	location := time.FixedZone("", -5*60*60)
+
+	tests := []struct {
+		name    string
+		parse   func() (time.Time, error)
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "Alert.DateTime",
+			parse: func() (time.Time, error) {
+				return (&Alert{Date: pointer("2024-01-15"), Time: pointer("07:30:00")}).DateTime(location)
+			},
+			want: time.Date(2024, time.January, 15, 7, 30, 0, 0, location),
+		},
+		{
+			name: "Alert.DateTime without time",
+			parse: func() (time.Time, error) {
+				return (&Alert{Date: pointer("2024-01-15")}).DateTime(location)
+			},
+			wantErr: ErrFieldNotSet,
+		},
+		{
+			name: "Alert.DateTime without location",
+			parse: func() (time.Time, error) {
+				return (&Alert{Date: pointer("2024-01-15"), Time: pointer("07:30:00")}).DateTime(nil)
+			},
+			wantErr: ErrNoTimeZone,
+		},
+		{
+			name: "Event.StartDateTime",
+			parse: func() (time.Time, error) {
+				return (&Event{StartDate: pointer("2024-01-15"), StartTime: pointer("22:00:00")}).StartDateTime(location)
+			},
+			want: time.Date(2024, time.January, 15, 22, 0, 0, 0, location),
+		},
+		{
+			name: "Event.EndDateTime",
+			parse: func() (time.Time, error) {
+				return (&Event{EndDate: pointer("2024-01-16"), EndTime: pointer("06:00:00")}).EndDateTime(location)
+			},
+			want: time.Date(2024, time.January, 16, 6, 0, 0, 0, location),
+		},
+		{
+			name: "EquipmentSetting.FilterLastChangedValue",
+			parse: func() (time.Time, error) {
+				return (&EquipmentSetting{FilterLastChanged: pointer("2024-01-15")}).FilterLastChangedValue(location)
+			},
+			want: time.Date(2024, time.January, 15, 0, 0, 0, 0, location),
+		},
+		{
+			name: "Runtime.LastModifiedValue",
+			parse: func() (time.Time, error) {
+				return (&Runtime{LastModified: pointer("2024-01-15 12:30:00")}).LastModifiedValue()
+			},
+			want: time.Date(2024, time.January, 15, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Runtime.LastStatusModifiedValue",
+			parse: func() (time.Time, error) {
+				return (&Runtime{LastStatusModified: pointer("2024-01-15 12:30:00")}).LastStatusModifiedValue()
+			},
+			want: time.Date(2024, time.January, 15, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Runtime.LastModifiedValue not set",
+			parse: func() (time.Time, error) {
+				return (&Runtime{}).LastModifiedValue()
+			},
+			wantErr: ErrFieldNotSet,
+		},
+		{
+			name: "Weather.TimestampValue",
+			parse: func() (time.Time, error) {
+				return (&Weather{Timestamp: pointer("2024-01-15 12:30:00")}).TimestampValue()
+			},
+			want: time.Date(2024, time.January, 15, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Weather.TimestampValue malformed",
+			parse: func() (time.Time, error) {
+				return (&Weather{Timestamp: pointer("2024-01-15T12:30:00Z")}).TimestampValue()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.parse()
+
+			if test.want.IsZero() {
+				if err == nil || (test.wantErr != nil && !errors.Is(err, test.wantErr)) {
+					t.Fatalf("%s() = %s, %v, want error %v", test.name, got, err, test.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("%s() error = %v", test.name, err)
+			}
+
+			if !got.Equal(test.want) {
+				t.Errorf("%s() = %s, want %s", test.name, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+/*
+Package objects provides the ecobee API objects exchanged by the ecobee
+Client.
+
+For more information about the ecobee objects, see the documentation:
+https://www.ecobee.com/home/developer/api/documentation/v1/objects/Thermostat.shtml
+
+# Enumerated values
+
+Fields holding an enumerated value, such as Settings.HVACMode, are typed with
+a string type whose supported values are declared as constants. Values
+introduced by ecobee after this package was written are not reported as
+valid by IsValid but are preserved when decoded and encoded.
+*/
+package objects
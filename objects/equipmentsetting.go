@@ -1,5 +1,7 @@
 package objects
 
+import "time"
+
 // The EquipmentSetting object represents the alert/reminder type which is
 // associated with and dependent upon specific equipment controlled by the
 // Thermostat. It is used when getting/setting the Thermostat
@@ -46,3 +48,9 @@ type EquipmentSetting struct {
 	// the technician/contractor associated with the thermostat.
 	RemindTechnician *bool `json:"remindTechnician,omitempty"`
 }
+
+// FilterLastChangedValue returns FilterLastChanged parsed as midnight in
+// location, the thermostat's time zone. See Thermostat.TimeLocation.
+func (e *EquipmentSetting) FilterLastChangedValue(location *time.Location) (time.Time, error) {
+	return parseTime("filterLastChanged", dateLayout, e.FilterLastChanged, location)
+}
@@ -0,0 +1,179 @@
+package objects
+
+import (
+	"sort"
+	"strings"
+)
+
+// An Equipment specifies a piece of HVAC equipment reported by
+// Thermostat.EquipmentStatus.
+type Equipment string
+
+// Supported Equipment values.
+const (
+	EquipmentHeatPump     Equipment = "heatPump"
+	EquipmentHeatPump2    Equipment = "heatPump2"
+	EquipmentHeatPump3    Equipment = "heatPump3"
+	EquipmentCompCool1    Equipment = "compCool1"
+	EquipmentCompCool2    Equipment = "compCool2"
+	EquipmentAuxHeat1     Equipment = "auxHeat1"
+	EquipmentAuxHeat2     Equipment = "auxHeat2"
+	EquipmentAuxHeat3     Equipment = "auxHeat3"
+	EquipmentFan          Equipment = "fan"
+	EquipmentHumidifier   Equipment = "humidifier"
+	EquipmentDehumidifier Equipment = "dehumidifier"
+	EquipmentVentilator   Equipment = "ventilator"
+	EquipmentEconomizer   Equipment = "economizer"
+	EquipmentCompHotWater Equipment = "compHotWater"
+	EquipmentAuxHotWater  Equipment = "auxHotWater"
+)
+
+// IsValid reports whether v is one of the supported Equipment values.
+func (v Equipment) IsValid() bool {
+	switch v {
+	case EquipmentHeatPump, EquipmentHeatPump2, EquipmentHeatPump3, EquipmentCompCool1, EquipmentCompCool2, EquipmentAuxHeat1, EquipmentAuxHeat2, EquipmentAuxHeat3, EquipmentFan, EquipmentHumidifier, EquipmentDehumidifier, EquipmentVentilator, EquipmentEconomizer, EquipmentCompHotWater, EquipmentAuxHotWater:
+		return true
+	}
+
+	return false
+}
+
+// An EquipmentStatus is the set of equipment currently running.
+type EquipmentStatus map[Equipment]struct{}
+
+// ParseEquipmentStatus parses a comma separated list of the equipment
+// currently running, as returned in Thermostat.EquipmentStatus. An empty
+// string is parsed as an empty set.
+func ParseEquipmentStatus(equipmentStatus string) EquipmentStatus {
+	status := EquipmentStatus{}
+
+	for _, equipment := range strings.Split(equipmentStatus, ",") {
+		if equipment = strings.TrimSpace(equipment); equipment != "" {
+			status[Equipment(equipment)] = struct{}{}
+		}
+	}
+
+	return status
+}
+
+// Contains reports whether equipment is running.
+func (s EquipmentStatus) Contains(equipment Equipment) bool {
+	_, ok := s[equipment]
+
+	return ok
+}
+
+// ContainsAny reports whether any of equipment is running.
+func (s EquipmentStatus) ContainsAny(equipment ...Equipment) bool {
+	for _, e := range equipment {
+		if s.Contains(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Count returns the number of equipment in equipment that is running.
+func (s EquipmentStatus) Count(equipment ...Equipment) int {
+	count := 0
+
+	for _, e := range equipment {
+		if s.Contains(e) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Equipment returns the equipment currently running, sorted.
+func (s EquipmentStatus) Equipment() []Equipment {
+	equipment := make([]Equipment, 0, len(s))
+
+	for e := range s {
+		equipment = append(equipment, e)
+	}
+
+	sort.Slice(equipment, func(i, j int) bool {
+		return equipment[i] < equipment[j]
+	})
+
+	return equipment
+}
+
+// String returns the equipment currently running as a sorted comma separated
+// list.
+func (s EquipmentStatus) String() string {
+	equipment := s.Equipment()
+	values := make([]string, len(equipment))
+
+	for i, e := range equipment {
+		values[i] = string(e)
+	}
+
+	return strings.Join(values, ",")
+}
+
+// An HVACActivity specifies what the HVAC system is doing.
+type HVACActivity string
+
+// Supported HVACActivity values.
+const (
+	// No heating, cooling or fan equipment is running.
+	HVACActivityIdle HVACActivity = "idle"
+	// The primary heat source is running: the heat pump compressor if the
+	// thermostat controls a heat pump, otherwise the furnace or boiler.
+	HVACActivityHeating HVACActivity = "heating"
+	// The cooling compressor is running.
+	HVACActivityCooling HVACActivity = "cooling"
+	// The auxiliary heat source of a heat pump is running.
+	HVACActivityAuxHeating HVACActivity = "auxHeating"
+	// Only the fan is running.
+	HVACActivityFanOnly HVACActivity = "fanOnly"
+)
+
+// An HVACState describes the state of the HVAC system derived from the
+// equipment currently running and the thermostat's settings.
+type HVACState struct {
+	// What the HVAC system is doing.
+	Activity HVACActivity
+	// The number of heating or cooling stages running for Activity. It is 0
+	// when the system is idle or only the fan is running.
+	Stages int
+	// Whether the fan is running.
+	Fan bool
+}
+
+// HVACState returns the state of the HVAC system. Only Settings.HasHeatPump
+// is read from settings: auxHeat is auxiliary heat if it is true, otherwise it
+// is the primary heat source, whatever its type (e.g. furnace or boiler). A
+// nil settings is treated as a system without a heat pump. If both auxiliary
+// and heat pump heat run, the state is auxiliary heating.
+func (s EquipmentStatus) HVACState(settings *Settings) HVACState {
+	hasHeatPump := settings != nil && settings.HasHeatPump != nil && *settings.HasHeatPump
+
+	state := HVACState{
+		Activity: HVACActivityIdle,
+		Fan:      s.Contains(EquipmentFan),
+	}
+
+	heatPumpStages := s.Count(EquipmentHeatPump, EquipmentHeatPump2, EquipmentHeatPump3)
+	auxHeatStages := s.Count(EquipmentAuxHeat1, EquipmentAuxHeat2, EquipmentAuxHeat3)
+	coolStages := s.Count(EquipmentCompCool1, EquipmentCompCool2)
+
+	switch {
+	case coolStages > 0:
+		state.Activity, state.Stages = HVACActivityCooling, coolStages
+	case hasHeatPump && auxHeatStages > 0:
+		state.Activity, state.Stages = HVACActivityAuxHeating, auxHeatStages
+	case heatPumpStages > 0:
+		state.Activity, state.Stages = HVACActivityHeating, heatPumpStages
+	case auxHeatStages > 0:
+		state.Activity, state.Stages = HVACActivityHeating, auxHeatStages
+	case state.Fan:
+		state.Activity = HVACActivityFanOnly
+	}
+
+	return state
+}
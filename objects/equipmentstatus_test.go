@@ -0,0 +1,131 @@
+package objects
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEquipmentStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		equipmentStatus string
+		want            []Equipment
+		wantString      string
+	}{
+		{
+			name:            "idle",
+			equipmentStatus: "",
+			want:            []Equipment{},
+		},
+		{
+			name:            "running equipment",
+			equipmentStatus: "fan,heatPump, auxHeat1",
+			want:            []Equipment{EquipmentAuxHeat1, EquipmentFan, EquipmentHeatPump},
+			wantString:      "auxHeat1,fan,heatPump",
+		},
+		{
+			name:            "empty entries",
+			equipmentStatus: ",compCool1,,",
+			want:            []Equipment{EquipmentCompCool1},
+			wantString:      "compCool1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := ParseEquipmentStatus(test.equipmentStatus)
+
+			if got := status.Equipment(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Equipment() = %v, want %v", got, test.want)
+			}
+
+			if got := status.String(); got != test.wantString {
+				t.Errorf("String() = %q, want %q", got, test.wantString)
+			}
+		})
+	}
+}
+
+func TestEquipmentStatusContains(t *testing.T) {
+	status := ParseEquipmentStatus("heatPump,heatPump2,fan")
+
+	if !status.Contains(EquipmentFan) || status.Contains(EquipmentCompCool1) {
+		t.Errorf("Contains() reports %v incorrectly", status)
+	}
+
+	if !status.ContainsAny(EquipmentCompCool1, EquipmentHeatPump2) || status.ContainsAny(EquipmentCompCool1, EquipmentAuxHeat1) {
+		t.Errorf("ContainsAny() reports %v incorrectly", status)
+	}
+
+	if got := status.Count(EquipmentHeatPump, EquipmentHeatPump2, EquipmentHeatPump3); got != 2 {
+		t.Errorf("Count() = %d, want 2", got)
+	}
+}
+
+func TestEquipmentStatusHVACState(t *testing.T) {
+	heatPump := &Settings{HasHeatPump: pointer(true)}
+	furnace := &Settings{HasHeatPump: pointer(false), HasForcedAir: pointer(true)}
+	boiler := &Settings{HasHeatPump: pointer(false), HasBoiler: pointer(true)}
+
+	tests := []struct {
+		name            string
+		equipmentStatus string
+		settings        *Settings
+		want            HVACState
+	}{
+		{
+			name:     "idle",
+			settings: heatPump,
+			want:     HVACState{Activity: HVACActivityIdle},
+		},
+		{
+			name:            "fan only",
+			equipmentStatus: "fan",
+			settings:        furnace,
+			want:            HVACState{Activity: HVACActivityFanOnly, Fan: true},
+		},
+		{
+			name:            "two stage cooling",
+			equipmentStatus: "compCool1,compCool2,fan",
+			settings:        heatPump,
+			want:            HVACState{Activity: HVACActivityCooling, Stages: 2, Fan: true},
+		},
+		{
+			name:            "heat pump heating",
+			equipmentStatus: "heatPump,fan",
+			settings:        heatPump,
+			want:            HVACState{Activity: HVACActivityHeating, Stages: 1, Fan: true},
+		},
+		{
+			name:            "heat pump with auxiliary heat",
+			equipmentStatus: "heatPump,auxHeat1,auxHeat2,fan",
+			settings:        heatPump,
+			want:            HVACState{Activity: HVACActivityAuxHeating, Stages: 2, Fan: true},
+		},
+		{
+			name:            "furnace heating",
+			equipmentStatus: "auxHeat1,auxHeat2,fan",
+			settings:        furnace,
+			want:            HVACState{Activity: HVACActivityHeating, Stages: 2, Fan: true},
+		},
+		{
+			name:            "boiler heating",
+			equipmentStatus: "auxHeat1",
+			settings:        boiler,
+			want:            HVACState{Activity: HVACActivityHeating, Stages: 1},
+		},
+		{
+			name:            "nil settings",
+			equipmentStatus: "auxHeat1",
+			want:            HVACState{Activity: HVACActivityHeating, Stages: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ParseEquipmentStatus(test.equipmentStatus).HVACState(test.settings); got != test.want {
+				t.Errorf("HVACState() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package objects
 
+import "time"
+
 // The event object represents a scheduled thermostat program change. All events
 // have a start and end time during which the thermostat runtime settings will
 // be modified. Events may not be directly modified, various Functions provide
@@ -15,7 +17,7 @@ package objects
 type Event struct {
 	// The type of event. Values: hold, demandResponse, sensor, switchOccupancy,
 	// vacation, quickSave, today, autoAway, autoHome
-	Type *string `json:"type,omitempty"`
+	Type *EventType `json:"type,omitempty"`
 
 	// The unique event name.
 	Name *string `json:"name,omitempty"`
@@ -55,7 +57,7 @@ type Event struct {
 	Fan *string `json:"fan,omitempty"`
 
 	// The ventilator mode during the vent. Values: auto, minontime, on, off.
-	Vent *string `json:"vent,omitempty"`
+	Vent *VentMode `json:"vent,omitempty"`
 
 	// The minimum amount of time the ventilator equipment must stay on on each
 	// duty cycle.
@@ -110,3 +112,71 @@ type Event struct {
 	// low, medium, high, and optimized. 
 	FanSpeed *string `json:"fanSpeed,omitempty"`
 }
+
+// StartDateTime returns StartDate and StartTime parsed in location, the
+// thermostat's time zone. See Thermostat.TimeLocation.
+func (e *Event) StartDateTime(location *time.Location) (time.Time, error) {
+	return parseDateAndTime("startDate", e.StartDate, "startTime", e.StartTime, location)
+}
+
+// EndDateTime returns EndDate and EndTime parsed in location, the thermostat's
+// time zone. See Thermostat.TimeLocation.
+func (e *Event) EndDateTime(location *time.Location) (time.Time, error) {
+	return parseDateAndTime("endDate", e.EndDate, "endTime", e.EndTime, location)
+}
+
+// CoolHoldTempValue returns CoolHoldTemp as a Temperature, and whether it is
+// set.
+func (e *Event) CoolHoldTempValue() (Temperature, bool) {
+	return temperature(e.CoolHoldTemp)
+}
+
+// HeatHoldTempValue returns HeatHoldTemp as a Temperature, and whether it is
+// set.
+func (e *Event) HeatHoldTempValue() (Temperature, bool) {
+	return temperature(e.HeatHoldTemp)
+}
+
+// CoolRelativeTempValue returns CoolRelativeTemp as a TemperatureDelta, and
+// whether it is set.
+func (e *Event) CoolRelativeTempValue() (TemperatureDelta, bool) {
+	return temperatureDelta(e.CoolRelativeTemp)
+}
+
+// HeatRelativeTempValue returns HeatRelativeTemp as a TemperatureDelta, and
+// whether it is set.
+func (e *Event) HeatRelativeTempValue() (TemperatureDelta, bool) {
+	return temperatureDelta(e.HeatRelativeTemp)
+}
+
+// DRRampUpTempValue returns DRRampUpTemp as a TemperatureDelta, and whether it
+// is set.
+func (e *Event) DRRampUpTempValue() (TemperatureDelta, bool) {
+	return temperatureDelta(e.DRRampUpTemp)
+}
+
+// An EventType specifies the type of an Event.
+type EventType string
+
+// Supported EventType values.
+const (
+	EventTypeHold            EventType = "hold"
+	EventTypeDemandResponse  EventType = "demandResponse"
+	EventTypeSensor          EventType = "sensor"
+	EventTypeSwitchOccupancy EventType = "switchOccupancy"
+	EventTypeVacation        EventType = "vacation"
+	EventTypeQuickSave       EventType = "quickSave"
+	EventTypeToday           EventType = "today"
+	EventTypeAutoAway        EventType = "autoAway"
+	EventTypeAutoHome        EventType = "autoHome"
+)
+
+// IsValid reports whether v is one of the supported EventType values.
+func (v EventType) IsValid() bool {
+	switch v {
+	case EventTypeHold, EventTypeDemandResponse, EventTypeSensor, EventTypeSwitchOccupancy, EventTypeVacation, EventTypeQuickSave, EventTypeToday, EventTypeAutoAway, EventTypeAutoHome:
+		return true
+	}
+
+	return false
+}
@@ -23,7 +23,7 @@ type RemoteSensor struct {
 
 	// The type of sensor. Values: thermostat, ecobee3_remote_sensor,
 	// monitor_sensor, control_sensor.
-	Type *string `json:"type,omitempty"`
+	Type *RemoteSensorType `json:"type,omitempty"`
 
 	// The unique 4-digit alphanumeric sensor code. For ecobee3 remote sensors this
 	// corresponds to the code found on the back of the physical sensor.
@@ -36,3 +36,24 @@ type RemoteSensor struct {
 	// The list of remoteSensorCapability objects for the remote sensor.
 	Capability []RemoteSensorCapability `json:"capability,omitempty"`
 }
+
+// A RemoteSensorType specifies the type of a RemoteSensor.
+type RemoteSensorType string
+
+// Supported RemoteSensorType values.
+const (
+	RemoteSensorTypeThermostat          RemoteSensorType = "thermostat"
+	RemoteSensorTypeEcobee3RemoteSensor RemoteSensorType = "ecobee3_remote_sensor"
+	RemoteSensorTypeMonitorSensor       RemoteSensorType = "monitor_sensor"
+	RemoteSensorTypeControlSensor       RemoteSensorType = "control_sensor"
+)
+
+// IsValid reports whether v is one of the supported RemoteSensorType values.
+func (v RemoteSensorType) IsValid() bool {
+	switch v {
+	case RemoteSensorTypeThermostat, RemoteSensorTypeEcobee3RemoteSensor, RemoteSensorTypeMonitorSensor, RemoteSensorTypeControlSensor:
+		return true
+	}
+
+	return false
+}
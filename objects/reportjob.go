@@ -15,7 +15,7 @@ type ReportJob struct {
 
 	// The current status of the job. Values: queued, processing, completed,
 	// cancelled, error.
-	Status *string `json:"status,omitempty"`
+	Status *ReportJobStatus `json:"status,omitempty"`
 
 	// The message indicating why the job failed (if status=error).
 	Message *string `json:"message,omitempty"`
@@ -23,3 +23,25 @@ type ReportJob struct {
 	// The list of uploaded file URLs for the corresponding job (if status=completed).
 	Files []string `json:"files,omitempty"`
 }
+
+// A ReportJobStatus specifies the status of a ReportJob.
+type ReportJobStatus string
+
+// Supported ReportJobStatus values.
+const (
+	ReportJobStatusQueued     ReportJobStatus = "queued"
+	ReportJobStatusProcessing ReportJobStatus = "processing"
+	ReportJobStatusCompleted  ReportJobStatus = "completed"
+	ReportJobStatusCancelled  ReportJobStatus = "cancelled"
+	ReportJobStatusError      ReportJobStatus = "error"
+)
+
+// IsValid reports whether v is one of the supported ReportJobStatus values.
+func (v ReportJobStatus) IsValid() bool {
+	switch v {
+	case ReportJobStatusQueued, ReportJobStatusProcessing, ReportJobStatusCompleted, ReportJobStatusCancelled, ReportJobStatusError:
+		return true
+	}
+
+	return false
+}
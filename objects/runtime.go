@@ -1,5 +1,7 @@
 package objects
 
+import "time"
+
 // The runtime object represents the last known thermostat running state. This
 // state is composed from the last interval status message received from a
 // thermostat. It is also updated each time the thermostat posts configuration
@@ -105,3 +107,49 @@ type Runtime struct {
 	// e.g. [650,920].
 	DesiredCoolRange []int `json:"desiredCoolRange,omitempty"`
 }
+
+// LastModifiedValue returns LastModified parsed as a UTC time.
+func (r *Runtime) LastModifiedValue() (time.Time, error) {
+	return parseTime("lastModified", dateTimeLayout, r.LastModified, time.UTC)
+}
+
+// LastStatusModifiedValue returns LastStatusModified parsed as a UTC time.
+func (r *Runtime) LastStatusModifiedValue() (time.Time, error) {
+	return parseTime("lastStatusModified", dateTimeLayout, r.LastStatusModified, time.UTC)
+}
+
+// ActualTemperatureValue returns ActualTemperature as a Temperature, and
+// whether it is set.
+func (r *Runtime) ActualTemperatureValue() (Temperature, bool) {
+	return temperature(r.ActualTemperature)
+}
+
+// RawTemperatureValue returns RawTemperature as a Temperature, and whether it
+// is set.
+func (r *Runtime) RawTemperatureValue() (Temperature, bool) {
+	return temperature(r.RawTemperature)
+}
+
+// DesiredHeatValue returns DesiredHeat as a Temperature, and whether it is
+// set.
+func (r *Runtime) DesiredHeatValue() (Temperature, bool) {
+	return temperature(r.DesiredHeat)
+}
+
+// DesiredCoolValue returns DesiredCool as a Temperature, and whether it is
+// set.
+func (r *Runtime) DesiredCoolValue() (Temperature, bool) {
+	return temperature(r.DesiredCool)
+}
+
+// DesiredHeatRangeValue returns the minimum and maximum of DesiredHeatRange as
+// Temperatures, and whether it is set.
+func (r *Runtime) DesiredHeatRangeValue() (Temperature, Temperature, bool) {
+	return temperatureRange(r.DesiredHeatRange)
+}
+
+// DesiredCoolRangeValue returns the minimum and maximum of DesiredCoolRange as
+// Temperatures, and whether it is set.
+func (r *Runtime) DesiredCoolRangeValue() (Temperature, Temperature, bool) {
+	return temperatureRange(r.DesiredCoolRange)
+}
@@ -0,0 +1,308 @@
+package objects
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Dimensions of a Program schedule: one row per day starting on Monday, one
+// column per half hour starting at midnight.
+const (
+	ScheduleDays        = 7
+	ScheduleSlotsPerDay = 48
+)
+
+const scheduleSlotDuration = 30 * time.Minute
+
+// Day sets commonly passed to the Schedule methods.
+var (
+	Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	Weekend  = []time.Weekday{time.Saturday, time.Sunday}
+	AllDays  = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+)
+
+// A Schedule is an editable Program schedule. Every half hour of the week is
+// assigned the climateRef of the Climate that runs during it.
+type Schedule struct {
+	slots [ScheduleDays][ScheduleSlotsPerDay]string
+}
+
+// ParseSchedule parses a schedule in the form of Program.Schedule: 7 rows of
+// 48 climateRefs, starting on Monday at midnight.
+func ParseSchedule(schedule [][]string) (*Schedule, error) {
+	if len(schedule) != ScheduleDays {
+		return nil, fmt.Errorf("schedule: expected %d days, got %d", ScheduleDays, len(schedule))
+	}
+
+	s := &Schedule{}
+
+	for day, slots := range schedule {
+		if len(slots) != ScheduleSlotsPerDay {
+			return nil, fmt.Errorf("schedule: %s: expected %d slots, got %d", scheduleWeekday(day), ScheduleSlotsPerDay, len(slots))
+		}
+
+		copy(s.slots[day][:], slots)
+	}
+
+	return s, nil
+}
+
+// ClimateRef returns the climateRef running on day at the half hour slot
+// containing timeOfDay, formatted as HH:MM.
+func (s *Schedule) ClimateRef(day time.Weekday, timeOfDay string) (string, error) {
+	row, err := scheduleDay(day)
+	if err != nil {
+		return "", err
+	}
+
+	t, err := parseScheduleTime(timeOfDay, false, false)
+	if err != nil {
+		return "", err
+	}
+
+	return s.slots[row][t/scheduleSlotDuration], nil
+}
+
+// SetDays sets every half hour of days to climateRef. The schedule is not
+// modified if any of days is invalid.
+func (s *Schedule) SetDays(days []time.Weekday, climateRef string) error {
+	rows, err := scheduleDays(days)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		for slot := range s.slots[row] {
+			s.slots[row][slot] = climateRef
+		}
+	}
+
+	return nil
+}
+
+// SetRange sets the half hours from start to end of days to climateRef. start
+// and end are formatted as HH:MM and must fall on a half hour; end may be
+// 24:00. start and end must differ; use SetDays to set whole days. If end is
+// before start the range wraps past midnight into the following day, so
+//
+//	s.SetRange(objects.Weekdays, "22:00", "06:30", "sleep")
+//
+// sets Monday 22:00 through Tuesday 06:30, and so on until Friday 22:00
+// through Saturday 06:30. The schedule is not modified if an error is
+// returned.
+func (s *Schedule) SetRange(days []time.Weekday, start string, end string, climateRef string) error {
+	rows, err := scheduleDays(days)
+	if err != nil {
+		return err
+	}
+
+	startTime, err := parseScheduleTime(start, true, false)
+	if err != nil {
+		return err
+	}
+
+	endTime, err := parseScheduleTime(end, true, true)
+	if err != nil {
+		return err
+	}
+
+	startSlot, endSlot := int(startTime/scheduleSlotDuration), int(endTime/scheduleSlotDuration)
+
+	if endSlot == startSlot {
+		return fmt.Errorf("schedule: start and end %q are equal", start)
+	}
+
+	if endSlot < startSlot {
+		endSlot += ScheduleSlotsPerDay
+	}
+
+	for _, row := range rows {
+		for slot := startSlot; slot < endSlot; slot++ {
+			d := (row + slot/ScheduleSlotsPerDay) % ScheduleDays
+			s.slots[d][slot%ScheduleSlotsPerDay] = climateRef
+		}
+	}
+
+	return nil
+}
+
+// Validate reports every climateRef of the schedule that is not the
+// ClimateRef of one of climates, and every empty slot. All errors are joined
+// in the returned error.
+func (s *Schedule) Validate(climates []Climate) error {
+	climateRefs := make(map[string]bool, len(climates))
+
+	for _, climate := range climates {
+		if climate.ClimateRef != nil {
+			climateRefs[*climate.ClimateRef] = true
+		}
+	}
+
+	errs := []error{}
+	reported := map[string]bool{}
+
+	for day := range s.slots {
+		for slot, climateRef := range s.slots[day] {
+			if climateRefs[climateRef] || reported[climateRef] {
+				continue
+			}
+
+			reported[climateRef] = true
+
+			if climateRef == "" {
+				errs = append(errs, fmt.Errorf("schedule: empty climateRef, first at %s %s", scheduleWeekday(day), formatScheduleTime(slot)))
+			} else {
+				errs = append(errs, fmt.Errorf("schedule: unknown climateRef %q, first at %s %s", climateRef, scheduleWeekday(day), formatScheduleTime(slot)))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Render returns the schedule in the form of Program.Schedule.
+func (s *Schedule) Render() [][]string {
+	schedule := make([][]string, ScheduleDays)
+
+	for day := range s.slots {
+		schedule[day] = append([]string(nil), s.slots[day][:]...)
+	}
+
+	return schedule
+}
+
+// A ScheduleChange describes a range of consecutive half hours of a day whose
+// climateRef changed from the same climateRef to the same climateRef.
+type ScheduleChange struct {
+	// The day of the change.
+	Day time.Weekday
+	// The start of the change, formatted as HH:MM.
+	Start string
+	// The end of the change, formatted as HH:MM. It is 24:00 if the change
+	// lasts until midnight.
+	End string
+	// The previous climateRef.
+	From string
+	// The new climateRef.
+	To string
+}
+
+// Diff returns the changes from previous to s, in chronological order
+// starting on Monday. It returns an empty slice if the schedules are equal. A
+// nil previous is treated as a schedule with every slot empty.
+func (s *Schedule) Diff(previous *Schedule) []ScheduleChange {
+	if previous == nil {
+		previous = &Schedule{}
+	}
+
+	changes := []ScheduleChange{}
+
+	for day := range s.slots {
+		for slot := 0; slot < ScheduleSlotsPerDay; {
+			from, to := previous.slots[day][slot], s.slots[day][slot]
+			if from == to {
+				slot++
+
+				continue
+			}
+
+			end := slot + 1
+			for end < ScheduleSlotsPerDay && previous.slots[day][end] == from && s.slots[day][end] == to {
+				end++
+			}
+
+			changes = append(changes, ScheduleChange{
+				Day:   scheduleWeekday(day),
+				Start: formatScheduleTime(slot),
+				End:   formatScheduleTime(end),
+				From:  from,
+				To:    to,
+			})
+
+			slot = end
+		}
+	}
+
+	return changes
+}
+
+// ScheduleValue returns Schedule parsed as an editable Schedule.
+func (p *Program) ScheduleValue() (*Schedule, error) {
+	return ParseSchedule(p.Schedule)
+}
+
+// WithSchedule returns a copy of p whose Schedule is s rendered, after
+// validating that every climateRef of s is one of p's Climates. The copy can
+// be sent as the Program of a Thermostat in UpdateThermostat.
+func (p *Program) WithSchedule(s *Schedule) (*Program, error) {
+	if err := s.Validate(p.Climates); err != nil {
+		return nil, err
+	}
+
+	program := *p
+	program.Schedule = s.Render()
+
+	return &program, nil
+}
+
+// scheduleDay returns the schedule row of day. It returns an error if day is
+// not between time.Sunday and time.Saturday.
+func scheduleDay(day time.Weekday) (int, error) {
+	if day < time.Sunday || day > time.Saturday {
+		return 0, fmt.Errorf("schedule: invalid day %d", int(day))
+	}
+
+	return (int(day) + ScheduleDays - 1) % ScheduleDays, nil
+}
+
+// scheduleDays returns the schedule rows of days.
+func scheduleDays(days []time.Weekday) ([]int, error) {
+	rows := make([]int, len(days))
+
+	for i, day := range days {
+		row, err := scheduleDay(day)
+		if err != nil {
+			return nil, err
+		}
+
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+// scheduleWeekday returns the day of the schedule row day.
+func scheduleWeekday(day int) time.Weekday {
+	return time.Weekday((day + 1) % ScheduleDays)
+}
+
+// parseScheduleTime returns the time of day value, formatted as HH:MM. If
+// halfHour is true value must fall on a half hour. If end is true value may
+// be 24:00.
+func parseScheduleTime(value string, halfHour bool, end bool) (time.Duration, error) {
+	var hours, minutes int
+
+	if n, err := fmt.Sscanf(value, "%d:%d", &hours, &minutes); err != nil || n != 2 || len(value) != 5 {
+		return 0, fmt.Errorf("schedule: invalid time %q: expected HH:MM", value)
+	}
+
+	timeOfDay := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+
+	if hours < 0 || minutes < 0 || minutes > 59 || timeOfDay > 24*time.Hour || (timeOfDay == 24*time.Hour && !end) {
+		return 0, fmt.Errorf("schedule: invalid time %q: out of range", value)
+	}
+
+	if halfHour && timeOfDay%scheduleSlotDuration != 0 {
+		return 0, fmt.Errorf("schedule: invalid time %q: not on a half hour", value)
+	}
+
+	return timeOfDay, nil
+}
+
+// formatScheduleTime returns the start of slot formatted as HH:MM.
+func formatScheduleTime(slot int) string {
+	start := time.Duration(slot) * scheduleSlotDuration
+
+	return fmt.Sprintf("%02d:%02d", int(start.Hours()), int(start.Minutes())%60)
+}
@@ -0,0 +1,336 @@
+package objects
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestSchedule returns a schedule with every slot set to climateRef.
+func newTestSchedule(t *testing.T, climateRef string) *Schedule {
+	t.Helper()
+
+	s := &Schedule{}
+	if err := s.SetDays(AllDays, climateRef); err != nil {
+		t.Fatalf("SetDays() error = %v", err)
+	}
+
+	return s
+}
+
+func TestScheduleSetRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		days        []time.Weekday
+		start       string
+		end         string
+		wantSleep   []string
+		wantHome    []string
+		wantErr     bool
+		wantChanges []ScheduleChange
+	}{
+		{
+			name:      "within a day",
+			days:      []time.Weekday{time.Wednesday},
+			start:     "09:00",
+			end:       "17:30",
+			wantSleep: []string{"Wednesday 09:00", "Wednesday 17:00"},
+			wantHome:  []string{"Wednesday 08:30", "Wednesday 17:30"},
+			wantChanges: []ScheduleChange{
+				{Day: time.Wednesday, Start: "09:00", End: "17:30", From: "home", To: "sleep"},
+			},
+		},
+		{
+			name:      "until midnight",
+			days:      []time.Weekday{time.Friday},
+			start:     "22:00",
+			end:       "24:00",
+			wantSleep: []string{"Friday 22:00", "Friday 23:30"},
+			wantHome:  []string{"Friday 21:30", "Saturday 00:00"},
+			wantChanges: []ScheduleChange{
+				{Day: time.Friday, Start: "22:00", End: "24:00", From: "home", To: "sleep"},
+			},
+		},
+		{
+			name:      "wraps past midnight",
+			days:      []time.Weekday{time.Tuesday},
+			start:     "22:00",
+			end:       "06:30",
+			wantSleep: []string{"Tuesday 22:00", "Wednesday 00:00", "Wednesday 06:00"},
+			wantHome:  []string{"Tuesday 21:30", "Wednesday 06:30"},
+			wantChanges: []ScheduleChange{
+				{Day: time.Tuesday, Start: "22:00", End: "24:00", From: "home", To: "sleep"},
+				{Day: time.Wednesday, Start: "00:00", End: "06:30", From: "home", To: "sleep"},
+			},
+		},
+		{
+			name:      "wraps from Sunday to Monday",
+			days:      []time.Weekday{time.Sunday},
+			start:     "23:00",
+			end:       "01:00",
+			wantSleep: []string{"Sunday 23:00", "Monday 00:30"},
+			wantHome:  []string{"Sunday 22:30", "Monday 01:00"},
+			wantChanges: []ScheduleChange{
+				{Day: time.Monday, Start: "00:00", End: "01:00", From: "home", To: "sleep"},
+				{Day: time.Sunday, Start: "23:00", End: "24:00", From: "home", To: "sleep"},
+			},
+		},
+		{
+			name:    "start equals end",
+			days:    []time.Weekday{time.Monday},
+			start:   "08:00",
+			end:     "08:00",
+			wantErr: true,
+		},
+		{
+			name:    "not on a half hour",
+			days:    []time.Weekday{time.Monday},
+			start:   "08:15",
+			end:     "09:00",
+			wantErr: true,
+		},
+		{
+			name:    "start at midnight",
+			days:    []time.Weekday{time.Monday},
+			start:   "24:00",
+			end:     "06:00",
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			days:    []time.Weekday{time.Monday, 7},
+			start:   "08:00",
+			end:     "09:00",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous := newTestSchedule(t, "home")
+			s := newTestSchedule(t, "home")
+
+			err := s.SetRange(test.days, test.start, test.end, "sleep")
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("SetRange() error = nil, want an error")
+				}
+
+				if changes := s.Diff(previous); len(changes) != 0 {
+					t.Errorf("SetRange() modified the schedule: %+v", changes)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SetRange() error = %v", err)
+			}
+
+			for climateRef, slots := range map[string][]string{"sleep": test.wantSleep, "home": test.wantHome} {
+				for _, slot := range slots {
+					day, timeOfDay, _ := strings.Cut(slot, " ")
+
+					got, err := s.ClimateRef(parseTestWeekday(t, day), timeOfDay)
+					if err != nil {
+						t.Fatalf("ClimateRef(%s) error = %v", slot, err)
+					}
+
+					if got != climateRef {
+						t.Errorf("ClimateRef(%s) = %q, want %q", slot, got, climateRef)
+					}
+				}
+			}
+
+			if got := s.Diff(previous); !reflect.DeepEqual(got, test.wantChanges) {
+				t.Errorf("Diff() = %+v, want %+v", got, test.wantChanges)
+			}
+		})
+	}
+}
+
+func parseTestWeekday(t *testing.T, day string) time.Weekday {
+	t.Helper()
+
+	for _, weekday := range AllDays {
+		if weekday.String() == day {
+			return weekday
+		}
+	}
+
+	t.Fatalf("unknown day %q", day)
+
+	return 0
+}
+
+func TestScheduleInvalidDay(t *testing.T) {
+	s := newTestSchedule(t, "home")
+
+	for _, day := range []time.Weekday{-1, 7} {
+		if _, err := s.ClimateRef(day, "08:00"); err == nil {
+			t.Errorf("ClimateRef(%d) error = nil, want an error", day)
+		}
+
+		if err := s.SetDays([]time.Weekday{time.Monday, day}, "away"); err == nil {
+			t.Errorf("SetDays(%d) error = nil, want an error", day)
+		}
+	}
+
+	if changes := s.Diff(newTestSchedule(t, "home")); len(changes) != 0 {
+		t.Errorf("SetDays() modified the schedule: %+v", changes)
+	}
+}
+
+func TestScheduleClimateRef(t *testing.T) {
+	s := newTestSchedule(t, "home")
+
+	if err := s.SetRange([]time.Weekday{time.Monday}, "08:00", "08:30", "away"); err != nil {
+		t.Fatalf("SetRange() error = %v", err)
+	}
+
+	tests := []struct {
+		timeOfDay string
+		want      string
+		wantErr   bool
+	}{
+		{timeOfDay: "07:59", want: "home"},
+		{timeOfDay: "08:00", want: "away"},
+		{timeOfDay: "08:29", want: "away"},
+		{timeOfDay: "08:30", want: "home"},
+		{timeOfDay: "24:00", wantErr: true},
+		{timeOfDay: "8:00", wantErr: true},
+		{timeOfDay: "08:60", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.timeOfDay, func(t *testing.T) {
+			got, err := s.ClimateRef(time.Monday, test.timeOfDay)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ClimateRef() error = %v, want error %t", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("ClimateRef() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScheduleDiff(t *testing.T) {
+	previous := newTestSchedule(t, "home")
+	s := newTestSchedule(t, "home")
+
+	// Adjacent ranges with the same change are merged; a different To splits
+	// them.
+	for _, r := range []struct{ start, end, climateRef string }{
+		{"06:00", "07:00", "away"},
+		{"07:00", "08:00", "away"},
+		{"08:00", "09:00", "sleep"},
+	} {
+		if err := s.SetRange([]time.Weekday{time.Thursday}, r.start, r.end, r.climateRef); err != nil {
+			t.Fatalf("SetRange() error = %v", err)
+		}
+	}
+
+	want := []ScheduleChange{
+		{Day: time.Thursday, Start: "06:00", End: "08:00", From: "home", To: "away"},
+		{Day: time.Thursday, Start: "08:00", End: "09:00", From: "home", To: "sleep"},
+	}
+
+	if got := s.Diff(previous); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+
+	if got := s.Diff(s); len(got) != 0 {
+		t.Errorf("Diff() of an equal schedule = %+v, want none", got)
+	}
+
+	got := previous.Diff(nil)
+
+	if len(got) != ScheduleDays || got[0] != (ScheduleChange{Day: time.Monday, Start: "00:00", End: "24:00", To: "home"}) {
+		t.Errorf("Diff(nil) = %+v, want every day changed from empty to home", got)
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	climates := []Climate{{ClimateRef: pointer("home")}, {ClimateRef: pointer("sleep")}}
+
+	tests := []struct {
+		name        string
+		schedule    func(s *Schedule) error
+		wantErrs    []string
+		wantProgram bool
+	}{
+		{
+			name: "known climates",
+			schedule: func(s *Schedule) error {
+				return s.SetRange(AllDays, "22:00", "06:00", "sleep")
+			},
+			wantProgram: true,
+		},
+		{
+			name: "unknown climate reported once",
+			schedule: func(s *Schedule) error {
+				return s.SetRange(Weekdays, "09:00", "17:00", "away")
+			},
+			wantErrs: []string{`unknown climateRef "away", first at Monday 09:00`},
+		},
+		{
+			name: "empty slot",
+			schedule: func(s *Schedule) error {
+				s.slots[6][47] = ""
+
+				return nil
+			},
+			wantErrs: []string{"empty climateRef, first at Sunday 23:30"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestSchedule(t, "home")
+			if err := test.schedule(s); err != nil {
+				t.Fatalf("schedule error = %v", err)
+			}
+
+			err := s.Validate(climates)
+
+			if len(test.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatal("Validate() error = nil, want an error")
+				}
+
+				if got := strings.Count(err.Error(), "schedule:"); got != len(test.wantErrs) {
+					t.Errorf("Validate() error = %v, want %d errors", err, len(test.wantErrs))
+				}
+
+				for _, wantErr := range test.wantErrs {
+					if !strings.Contains(err.Error(), wantErr) {
+						t.Errorf("Validate() error = %v, want %q", err, wantErr)
+					}
+				}
+			}
+
+			program, err := (&Program{Climates: climates}).WithSchedule(s)
+			if (program != nil) != test.wantProgram {
+				t.Fatalf("WithSchedule() = %v, %v, want a program %t", program, err, test.wantProgram)
+			}
+
+			if program != nil {
+				parsed, err := program.ScheduleValue()
+				if err != nil {
+					t.Fatalf("ScheduleValue() error = %v", err)
+				}
+
+				if changes := parsed.Diff(s); len(changes) != 0 {
+					t.Errorf("ScheduleValue() differs from the rendered schedule: %+v", changes)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package objects
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Supported Selection.SelectionType values.
+const (
+	SelectionTypeThermostats   = "thermostats"
+	SelectionTypeRegistered    = "registered"
+	SelectionTypeManagementSet = "managementSet"
+)
+
+// An Include specifies an object to include in the response to a request
+// made with a Selection.
+type Include int
+
+// Supported Include values.
+const (
+	IncludeRuntime Include = iota
+	IncludeExtendedRuntime
+	IncludeElectricity
+	IncludeSettings
+	IncludeLocation
+	IncludeProgram
+	IncludeEvents
+	IncludeDevice
+	IncludeTechnician
+	IncludeUtility
+	IncludeManagement
+	IncludeAlerts
+	IncludeReminders
+	IncludeWeather
+	IncludeHouseDetails
+	IncludeOEMCfg
+	IncludeEquipmentStatus
+	IncludeNotificationSettings
+	IncludePrivacy
+	IncludeVersion
+	IncludeSecuritySettings
+	IncludeSensors
+	IncludeAudio
+	IncludeEnergy
+)
+
+// field returns the Selection field corresponding to i, or nil if i is not a
+// supported Include value.
+func (i Include) field(s *Selection) **bool {
+	switch i {
+	case IncludeRuntime:
+		return &s.IncludeRuntime
+	case IncludeExtendedRuntime:
+		return &s.IncludeExtendedRuntime
+	case IncludeElectricity:
+		return &s.IncludeElectricity
+	case IncludeSettings:
+		return &s.IncludeSettings
+	case IncludeLocation:
+		return &s.IncludeLocation
+	case IncludeProgram:
+		return &s.IncludeProgram
+	case IncludeEvents:
+		return &s.IncludeEvents
+	case IncludeDevice:
+		return &s.IncludeDevice
+	case IncludeTechnician:
+		return &s.IncludeTechnician
+	case IncludeUtility:
+		return &s.IncludeUtility
+	case IncludeManagement:
+		return &s.IncludeManagement
+	case IncludeAlerts:
+		return &s.IncludeAlerts
+	case IncludeReminders:
+		return &s.IncludeReminders
+	case IncludeWeather:
+		return &s.IncludeWeather
+	case IncludeHouseDetails:
+		return &s.IncludeHouseDetails
+	case IncludeOEMCfg:
+		return &s.IncludeOEMCfg
+	case IncludeEquipmentStatus:
+		return &s.IncludeEquipmentStatus
+	case IncludeNotificationSettings:
+		return &s.IncludeNotificationSettings
+	case IncludePrivacy:
+		return &s.IncludePrivacy
+	case IncludeVersion:
+		return &s.IncludeVersion
+	case IncludeSecuritySettings:
+		return &s.IncludeSecuritySettings
+	case IncludeSensors:
+		return &s.IncludeSensors
+	case IncludeAudio:
+		return &s.IncludeAudio
+	case IncludeEnergy:
+		return &s.IncludeEnergy
+	}
+
+	return nil
+}
+
+// A SelectionPreset is a named, reusable set of Include values.
+type SelectionPreset struct {
+	name     string
+	includes []Include
+}
+
+// NewSelectionPreset returns a SelectionPreset named name including includes.
+func NewSelectionPreset(name string, includes ...Include) SelectionPreset {
+	return SelectionPreset{
+		name:     name,
+		includes: append([]Include(nil), includes...),
+	}
+}
+
+// Name returns the preset's name.
+func (p SelectionPreset) Name() string {
+	return p.name
+}
+
+// Includes returns the preset's Include values.
+func (p SelectionPreset) Includes() []Include {
+	return append([]Include(nil), p.includes...)
+}
+
+// A SelectionBuilder builds a validated Selection. Its methods return the
+// builder so calls can be chained:
+//
+//	selection, err := objects.NewSelection().
+//		Thermostats("123456789012", "123456789013").
+//		With(objects.IncludeRuntime, objects.IncludeSensors).
+//		Build()
+type SelectionBuilder struct {
+	selectionType  *string
+	selectionMatch *string
+	includes       []Include
+}
+
+// NewSelection returns an empty SelectionBuilder.
+func NewSelection() *SelectionBuilder {
+	return &SelectionBuilder{}
+}
+
+// Thermostats selects the thermostats with the specified identifiers.
+func (b *SelectionBuilder) Thermostats(identifiers ...string) *SelectionBuilder {
+	return b.Match(SelectionTypeThermostats, strings.Join(identifiers, ","))
+}
+
+// Registered selects the thermostats registered to the current user.
+func (b *SelectionBuilder) Registered() *SelectionBuilder {
+	return b.Match(SelectionTypeRegistered, "")
+}
+
+// ManagementSet selects the thermostats of the specified management set path
+// (e.g. "/Toronto/Campus/BuildingA"). It is only available to EMS accounts.
+func (b *SelectionBuilder) ManagementSet(path string) *SelectionBuilder {
+	return b.Match(SelectionTypeManagementSet, path)
+}
+
+// Match sets the selection type and match verbatim. The values are validated
+// by Build.
+func (b *SelectionBuilder) Match(selectionType string, selectionMatch string) *SelectionBuilder {
+	b.selectionType = &selectionType
+	b.selectionMatch = &selectionMatch
+
+	return b
+}
+
+// With includes the specified objects in the response.
+func (b *SelectionBuilder) With(includes ...Include) *SelectionBuilder {
+	b.includes = append(b.includes, includes...)
+
+	return b
+}
+
+// Preset includes the objects of the specified preset in the response.
+func (b *SelectionBuilder) Preset(preset SelectionPreset) *SelectionBuilder {
+	return b.With(preset.includes...)
+}
+
+// Build validates and returns the Selection. All validation errors are
+// joined in the returned error.
+func (b *SelectionBuilder) Build() (*Selection, error) {
+	errs := []error{}
+
+	if b.selectionType == nil {
+		errs = append(errs, errors.New("selection: missing selection type"))
+	} else {
+		switch *b.selectionType {
+		case SelectionTypeThermostats:
+			for _, identifier := range strings.Split(*b.selectionMatch, ",") {
+				if strings.TrimSpace(identifier) == "" {
+					errs = append(errs, fmt.Errorf("selection: %s: empty thermostat identifier in selection match %q", *b.selectionType, *b.selectionMatch))
+
+					break
+				}
+			}
+		case SelectionTypeManagementSet:
+			if *b.selectionMatch == "" {
+				errs = append(errs, fmt.Errorf("selection: %s: missing management set path", *b.selectionType))
+			}
+		case SelectionTypeRegistered:
+			if *b.selectionMatch != "" {
+				errs = append(errs, fmt.Errorf("selection: %s: selection match must be empty, got %q", *b.selectionType, *b.selectionMatch))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("selection: invalid selection type %q", *b.selectionType))
+		}
+	}
+
+	selection := &Selection{}
+
+	if b.selectionType != nil {
+		selectionType, selectionMatch := *b.selectionType, *b.selectionMatch
+
+		selection.SelectionType = &selectionType
+		selection.SelectionMatch = &selectionMatch
+	}
+
+	for _, include := range b.includes {
+		field := include.field(selection)
+		if field == nil {
+			errs = append(errs, fmt.Errorf("selection: invalid include %d", include))
+
+			continue
+		}
+
+		included := true
+		*field = &included
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return selection, nil
+}
+
+// MustBuild is like Build but panics if the Selection is invalid. It
+// simplifies the initialization of package level selections.
+func (b *SelectionBuilder) MustBuild() *Selection {
+	selection, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	return selection
+}
@@ -4,7 +4,7 @@ package objects
 type Settings struct {
 	// The current HVAC mode the thermostat is in. Values: auto, auxHeatOnly, cool,
 	// heat, off.
-	HVACMode *string `json:"hvacMode,omitempty"`
+	HVACMode *HVACMode `json:"hvacMode,omitempty"`
 
 	// The last service date of the HVAC equipment.
 	LastServiceDate *string `json:"lastServiceDate,omitempty"`
@@ -19,7 +19,7 @@ type Settings struct {
 	RemindMeDate *string `json:"remindMeDate,omitempty"`
 
 	// The ventilator mode. Values: auto, minontime, on, off.
-	Vent *string `json:"vent,omitempty"`
+	Vent *VentMode `json:"vent,omitempty"`
 
 	// The minimum time in minutes the ventilator is configured to run. The
 	// thermostat will always guarantee that the ventilator runs for this minimum
@@ -403,3 +403,76 @@ type Settings struct {
 	// low, medium, high, and optimized.
 	FanSpeed *string `json:"fanSpeed,omitempty"`
 }
+
+// HeatMinTempValue returns HeatMinTemp as a Temperature, and whether it is
+// set.
+func (s *Settings) HeatMinTempValue() (Temperature, bool) {
+	return temperature(s.HeatMinTemp)
+}
+
+// HeatMaxTempValue returns HeatMaxTemp as a Temperature, and whether it is
+// set.
+func (s *Settings) HeatMaxTempValue() (Temperature, bool) {
+	return temperature(s.HeatMaxTemp)
+}
+
+// CoolMinTempValue returns CoolMinTemp as a Temperature, and whether it is
+// set.
+func (s *Settings) CoolMinTempValue() (Temperature, bool) {
+	return temperature(s.CoolMinTemp)
+}
+
+// CoolMaxTempValue returns CoolMaxTemp as a Temperature, and whether it is
+// set.
+func (s *Settings) CoolMaxTempValue() (Temperature, bool) {
+	return temperature(s.CoolMaxTemp)
+}
+
+// HeatCoolMinDeltaValue returns HeatCoolMinDelta as a TemperatureDelta, and
+// whether it is set.
+func (s *Settings) HeatCoolMinDeltaValue() (TemperatureDelta, bool) {
+	return temperatureDelta(s.HeatCoolMinDelta)
+}
+
+// An HVACMode specifies the HVAC mode of a thermostat.
+type HVACMode string
+
+// Supported HVACMode values.
+const (
+	HVACModeAuto        HVACMode = "auto"
+	HVACModeAuxHeatOnly HVACMode = "auxHeatOnly"
+	HVACModeCool        HVACMode = "cool"
+	HVACModeHeat        HVACMode = "heat"
+	HVACModeOff         HVACMode = "off"
+)
+
+// IsValid reports whether v is one of the supported HVACMode values.
+func (v HVACMode) IsValid() bool {
+	switch v {
+	case HVACModeAuto, HVACModeAuxHeatOnly, HVACModeCool, HVACModeHeat, HVACModeOff:
+		return true
+	}
+
+	return false
+}
+
+// A VentMode specifies a ventilator mode.
+type VentMode string
+
+// Supported VentMode values.
+const (
+	VentModeAuto      VentMode = "auto"
+	VentModeMinOnTime VentMode = "minontime"
+	VentModeOn        VentMode = "on"
+	VentModeOff       VentMode = "off"
+)
+
+// IsValid reports whether v is one of the supported VentMode values.
+func (v VentMode) IsValid() bool {
+	switch v {
+	case VentModeAuto, VentModeMinOnTime, VentModeOn, VentModeOff:
+		return true
+	}
+
+	return false
+}
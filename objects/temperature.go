@@ -0,0 +1,136 @@
+package objects
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Temperature is an absolute temperature encoded the way ecobee encodes
+// temperatures: an integer number of tenths of a degree Fahrenheit (e.g. 715
+// is 71.5°F).
+type Temperature int
+
+// TemperatureFromFahrenheit returns the Temperature of f degrees Fahrenheit,
+// rounded to the nearest tenth of a degree.
+func TemperatureFromFahrenheit(f float64) Temperature {
+	return Temperature(math.Round(f * 10))
+}
+
+// TemperatureFromCelsius returns the Temperature of c degrees Celsius,
+// rounded to the nearest tenth of a degree Fahrenheit.
+func TemperatureFromCelsius(c float64) Temperature {
+	return Temperature(math.Round((c*9/5 + 32) * 10))
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit.
+func (t Temperature) Fahrenheit() float64 {
+	return float64(t) / 10
+}
+
+// Celsius returns the temperature in degrees Celsius, unrounded.
+func (t Temperature) Celsius() float64 {
+	return (float64(t)/10 - 32) * 5 / 9
+}
+
+// DisplayFahrenheit returns the temperature in degrees Fahrenheit rounded the
+// way an ecobee thermostat displays it: to the nearest degree.
+func (t Temperature) DisplayFahrenheit() float64 {
+	return math.Round(t.Fahrenheit())
+}
+
+// DisplayCelsius returns the temperature in degrees Celsius rounded the way
+// an ecobee thermostat displays it: to the nearest half degree.
+func (t Temperature) DisplayCelsius() float64 {
+	return math.Round(t.Celsius()*2) / 2
+}
+
+// Tenths returns the temperature in tenths of a degree Fahrenheit, the value
+// expected by the ecobee API.
+func (t Temperature) Tenths() int {
+	return int(t)
+}
+
+// Add returns the temperature t+d.
+func (t Temperature) Add(d TemperatureDelta) Temperature {
+	return t + Temperature(d)
+}
+
+// Sub returns the difference t-u.
+func (t Temperature) Sub(u Temperature) TemperatureDelta {
+	return TemperatureDelta(t - u)
+}
+
+// String returns the temperature formatted in degrees Fahrenheit (e.g.
+// "71.5°F").
+func (t Temperature) String() string {
+	return fmt.Sprintf("%.1f°F", t.Fahrenheit())
+}
+
+// A TemperatureDelta is a temperature difference, such as a differential, an
+// offset or a relative setpoint, encoded in tenths of a degree Fahrenheit.
+// Unlike a Temperature it converts to Celsius without the 32°F offset.
+type TemperatureDelta int
+
+// TemperatureDeltaFromFahrenheit returns the TemperatureDelta of f degrees
+// Fahrenheit, rounded to the nearest tenth of a degree.
+func TemperatureDeltaFromFahrenheit(f float64) TemperatureDelta {
+	return TemperatureDelta(math.Round(f * 10))
+}
+
+// TemperatureDeltaFromCelsius returns the TemperatureDelta of c degrees
+// Celsius, rounded to the nearest tenth of a degree Fahrenheit.
+func TemperatureDeltaFromCelsius(c float64) TemperatureDelta {
+	return TemperatureDelta(math.Round(c * 9 / 5 * 10))
+}
+
+// Fahrenheit returns the difference in degrees Fahrenheit.
+func (d TemperatureDelta) Fahrenheit() float64 {
+	return float64(d) / 10
+}
+
+// Celsius returns the difference in degrees Celsius, unrounded.
+func (d TemperatureDelta) Celsius() float64 {
+	return float64(d) / 10 * 5 / 9
+}
+
+// Tenths returns the difference in tenths of a degree Fahrenheit, the value
+// expected by the ecobee API.
+func (d TemperatureDelta) Tenths() int {
+	return int(d)
+}
+
+// String returns the difference formatted in degrees Fahrenheit (e.g.
+// "1.5°F").
+func (d TemperatureDelta) String() string {
+	return fmt.Sprintf("%.1f°F", d.Fahrenheit())
+}
+
+// temperature returns the Temperature of a field in tenths of a degree
+// Fahrenheit, and whether the field is set.
+func temperature(tenths *int) (Temperature, bool) {
+	if tenths == nil {
+		return 0, false
+	}
+
+	return Temperature(*tenths), true
+}
+
+// temperatureDelta returns the TemperatureDelta of a field in tenths of a
+// degree Fahrenheit, and whether the field is set.
+func temperatureDelta(tenths *int) (TemperatureDelta, bool) {
+	if tenths == nil {
+		return 0, false
+	}
+
+	return TemperatureDelta(*tenths), true
+}
+
+// temperatureRange returns the bounds of a range field holding a minimum and
+// a maximum in tenths of a degree Fahrenheit, and whether the field is set.
+func temperatureRange(tenths []int) (Temperature, Temperature, bool) {
+	if len(tenths) != 2 {
+		return 0, 0, false
+	}
+
+	return Temperature(tenths[0]), Temperature(tenths[1]), true
+}
@@ -1,5 +1,10 @@
 package objects
 
+import (
+	"fmt"
+	"time"
+)
+
 // The thermostat object is the central piece of the ecobee API. All objects
 // relate in one way or another to a real thermostat. The thermostat object and
 // its component objects define the real thermostat device.
@@ -18,7 +23,7 @@ type Thermostat struct {
 
 	// The thermostat model number. Values: apolloSmart, apolloEms, idtSmart,
 	// idtEms, siSmart, siEms, athenaSmart, athenaEms, corSmart, nikeSmart, nikeEms
-	ModelNumber *string `json:"modelNumber,omitempty"`
+	ModelNumber *ModelNumber `json:"modelNumber,omitempty"`
 
 	// The thermostat brand.
 	Brand *string `json:"brand,omitempty"`
@@ -127,3 +132,78 @@ type Thermostat struct {
 	// The list of RemoteSensor objects for the Thermostat.
 	RemoteSensors []RemoteSensor `json:"remoteSensors,omitempty"`
 }
+
+// TimeLocation returns the thermostat's time zone, resolved from the Location
+// object. It returns ErrNoTimeZone if the Location object was not included in
+// the selection.
+func (t *Thermostat) TimeLocation() (*time.Location, error) {
+	return t.Location.TimeLocation()
+}
+
+// ThermostatTimeValue returns ThermostatTime parsed in the thermostat's time
+// zone. See TimeLocation.
+func (t *Thermostat) ThermostatTimeValue() (time.Time, error) {
+	location, err := t.TimeLocation()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("thermostatTime: %w", err)
+	}
+
+	return parseTime("thermostatTime", dateTimeLayout, t.ThermostatTime, location)
+}
+
+// UTCTimeValue returns UTCTime parsed as a UTC time.
+func (t *Thermostat) UTCTimeValue() (time.Time, error) {
+	return parseTime("utcTime", dateTimeLayout, t.UTCTime, time.UTC)
+}
+
+// EquipmentStatusValue returns EquipmentStatus parsed as a set. It returns an
+// empty set if EquipmentStatus is not set.
+func (t *Thermostat) EquipmentStatusValue() EquipmentStatus {
+	if t.EquipmentStatus == nil {
+		return EquipmentStatus{}
+	}
+
+	return ParseEquipmentStatus(*t.EquipmentStatus)
+}
+
+// HVACState returns the state of the thermostat's HVAC system. The
+// EquipmentStatus and Settings objects must be included in the selection.
+func (t *Thermostat) HVACState() (HVACState, error) {
+	if t.EquipmentStatus == nil {
+		return HVACState{}, fmt.Errorf("equipmentStatus: %w", ErrFieldNotSet)
+	}
+
+	if t.Settings == nil {
+		return HVACState{}, fmt.Errorf("settings: %w", ErrFieldNotSet)
+	}
+
+	return t.EquipmentStatusValue().HVACState(t.Settings), nil
+}
+
+// A ModelNumber specifies the model of a Thermostat.
+type ModelNumber string
+
+// Supported ModelNumber values.
+const (
+	ModelNumberApolloSmart ModelNumber = "apolloSmart"
+	ModelNumberApolloEMS   ModelNumber = "apolloEms"
+	ModelNumberIDTSmart    ModelNumber = "idtSmart"
+	ModelNumberIDTEMS      ModelNumber = "idtEms"
+	ModelNumberSISmart     ModelNumber = "siSmart"
+	ModelNumberSIEMS       ModelNumber = "siEms"
+	ModelNumberAthenaSmart ModelNumber = "athenaSmart"
+	ModelNumberAthenaEMS   ModelNumber = "athenaEms"
+	ModelNumberCorSmart    ModelNumber = "corSmart"
+	ModelNumberNikeSmart   ModelNumber = "nikeSmart"
+	ModelNumberNikeEMS     ModelNumber = "nikeEms"
+)
+
+// IsValid reports whether v is one of the supported ModelNumber values.
+func (v ModelNumber) IsValid() bool {
+	switch v {
+	case ModelNumberApolloSmart, ModelNumberApolloEMS, ModelNumberIDTSmart, ModelNumberIDTEMS, ModelNumberSISmart, ModelNumberSIEMS, ModelNumberAthenaSmart, ModelNumberAthenaEMS, ModelNumberCorSmart, ModelNumberNikeSmart, ModelNumberNikeEMS:
+		return true
+	}
+
+	return false
+}
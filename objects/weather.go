@@ -1,5 +1,7 @@
 package objects
 
+import "time"
+
 // The Weather object contains the weather and forecast information for the
 // thermostat's location.
 type Weather struct {
@@ -12,3 +14,8 @@ type Weather struct {
 	// The list of latest weather station forecasts.
 	Forecasts []WeatherForecast `json:"forecasts,omitempty"`
 }
+
+// TimestampValue returns Timestamp parsed as a UTC time.
+func (w *Weather) TimestampValue() (time.Time, error) {
+	return parseTime("timestamp", dateTimeLayout, w.Timestamp, time.UTC)
+}
@@ -0,0 +1,280 @@
+/*
+Package otelecobee provides OpenTelemetry instrumentation for the ecobee
+Client.
+
+The instrumentation creates a client span for every API call and records the
+latency and errors of the calls with the meter API. It is installed with
+WithInstrumentation:
+
+	client := ecobee.NewClient(
+		ecobee.WithToken(applicationKey, token),
+		otelecobee.WithInstrumentation(),
+	)
+
+# Calls and attempts
+
+A call retried according to the Client's RetryPolicy produces a single span,
+covering every attempt as well as the waits between attempts and for the
+Client's rate limits. Every attempt is recorded as an ecobee.attempt event on
+the span. The instruments are recorded once per call, after the last attempt.
+
+Spans carry the following attributes:
+
+  - ecobee.endpoint: the name of the API endpoint (e.g. thermostat).
+  - ecobee.selection.type: the selection type of the request, if any.
+  - ecobee.thermostat.identifiers: the thermostat identifiers of the
+    selection, if its type is thermostats.
+  - ecobee.attempts: the number of attempts made, greater than 1 if the call
+    was retried.
+  - ecobee.page: the page returned, if any.
+  - ecobee.status_code: the ecobee status code of the last response, if any.
+  - http.request.method and http.response.status_code.
+
+Attempt events carry the ecobee.attempt, http.response.status_code,
+ecobee.status_code and error.type attributes of the attempt.
+
+The following instruments are recorded with the ecobee.endpoint attribute:
+
+  - ecobee.client.request.duration: a histogram of the duration of every call
+    in seconds, including retries.
+  - ecobee.client.request.errors: a counter of failed calls, further
+    qualified by the error.type attribute: transport if the call failed
+    without a response, or else the ecobee status code or the HTTP status
+    code of the last response.
+*/
+package otelecobee
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sherif-fanous/go-ecobee"
+)
+
+const instrumentationName = "github.com/sherif-fanous/go-ecobee/otelecobee"
+
+// attemptEventName is the name of the span event recorded for every attempt.
+const attemptEventName = "ecobee.attempt"
+
+// Attribute keys set on spans, span events and instruments.
+const (
+	EndpointKey              = attribute.Key("ecobee.endpoint")
+	SelectionTypeKey         = attribute.Key("ecobee.selection.type")
+	ThermostatIdentifiersKey = attribute.Key("ecobee.thermostat.identifiers")
+	AttemptsKey              = attribute.Key("ecobee.attempts")
+	AttemptKey               = attribute.Key("ecobee.attempt")
+	PageKey                  = attribute.Key("ecobee.page")
+	StatusCodeKey            = attribute.Key("ecobee.status_code")
+	ErrorTypeKey             = attribute.Key("error.type")
+
+	httpRequestMethodKey      = attribute.Key("http.request.method")
+	httpResponseStatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// An Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider returns an Option that sets the TracerProvider used to
+// create spans. It defaults to the global TracerProvider.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tracerProvider
+	}
+}
+
+// WithMeterProvider returns an Option that sets the MeterProvider used to
+// create instruments. It defaults to the global MeterProvider.
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = meterProvider
+	}
+}
+
+// WithInstrumentation returns a function that initializes an ecobee Client
+// with CallMiddleware and Middleware, instrumenting every API call made by the
+// Client with OpenTelemetry.
+func WithInstrumentation(options ...Option) func(*ecobee.Client) {
+	callMiddleware := ecobee.WithCallMiddleware(CallMiddleware(options...))
+	middleware := ecobee.WithMiddleware(Middleware())
+
+	return func(c *ecobee.Client) {
+		callMiddleware(c)
+		middleware(c)
+	}
+}
+
+// callSpanKey is the context key of the span created by CallMiddleware.
+type callSpanKey struct{}
+
+// CallMiddleware returns an ecobee.CallMiddleware creating a span for every API
+// call and recording the call's duration and errors. Use WithInstrumentation
+// to also record the attempts of every call.
+func CallMiddleware(options ...Option) ecobee.CallMiddleware {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+	meter := c.meterProvider.Meter(instrumentationName)
+
+	durationHistogram, err := meter.Float64Histogram(
+		"ecobee.client.request.duration",
+		metric.WithDescription("Duration of ecobee API calls, including retries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	errorCounter, err := meter.Int64Counter(
+		"ecobee.client.request.errors",
+		metric.WithDescription("Number of failed ecobee API calls."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next ecobee.CallFunc) ecobee.CallFunc {
+		return func(ctx context.Context, call *ecobee.APICall) (*http.Response, error) {
+			endpointAttribute := EndpointKey.String(call.Endpoint)
+
+			ctx, span := tracer.Start(ctx, "ecobee "+call.Endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(callAttributes(call)...),
+			)
+			defer span.End()
+
+			start := time.Now()
+
+			resp, err := next(context.WithValue(ctx, callSpanKey{}, span), call)
+
+			durationHistogram.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(endpointAttribute))
+
+			span.SetAttributes(AttemptsKey.Int(call.Attempts))
+
+			errorType := responseErrorType(resp, err)
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				span.SetAttributes(httpResponseStatusCodeKey.Int(resp.StatusCode))
+
+				if page := ecobee.ResponsePage(resp); page != nil && page.Page != nil {
+					span.SetAttributes(PageKey.Int(*page.Page))
+				}
+
+				if status := ecobee.ResponseStatus(resp); status != nil && status.Code != nil {
+					span.SetAttributes(StatusCodeKey.Int(*status.Code))
+				}
+
+				if errorType != "" {
+					span.SetStatus(codes.Error, resp.Status)
+				}
+			}
+
+			if errorType != "" {
+				errorCounter.Add(ctx, 1, metric.WithAttributes(endpointAttribute, ErrorTypeKey.String(errorType)))
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// Middleware returns an ecobee.Middleware recording every attempt of a call
+// as an event on the span created by CallMiddleware. Attempts of calls made
+// without CallMiddleware are not recorded.
+func Middleware() ecobee.Middleware {
+	return func(next ecobee.RoundTripFunc) ecobee.RoundTripFunc {
+		return func(ctx context.Context, req *ecobee.APIRequest) (*http.Response, error) {
+			span, ok := ctx.Value(callSpanKey{}).(trace.Span)
+			if !ok {
+				return next(ctx, req)
+			}
+
+			resp, err := next(ctx, req)
+
+			attributes := []attribute.KeyValue{
+				AttemptKey.Int(req.Attempt),
+			}
+
+			if err == nil {
+				attributes = append(attributes, httpResponseStatusCodeKey.Int(resp.StatusCode))
+
+				if status := ecobee.ResponseStatus(resp); status != nil && status.Code != nil {
+					attributes = append(attributes, StatusCodeKey.Int(*status.Code))
+				}
+			}
+
+			if errorType := responseErrorType(resp, err); errorType != "" {
+				attributes = append(attributes, ErrorTypeKey.String(errorType))
+			}
+
+			span.AddEvent(attemptEventName, trace.WithAttributes(attributes...))
+
+			return resp, err
+		}
+	}
+}
+
+// responseErrorType returns the error.type of a call or attempt that returned
+// resp and err, or an empty string if it succeeded.
+func responseErrorType(resp *http.Response, err error) string {
+	if err != nil {
+		return "transport"
+	}
+
+	if status := ecobee.ResponseStatus(resp); status != nil && status.Code != nil {
+		if *status.Code != int(ecobee.StatusCodeSuccess) {
+			return strconv.Itoa(*status.Code)
+		}
+
+		return ""
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return strconv.Itoa(resp.StatusCode)
+	}
+
+	return ""
+}
+
+func callAttributes(call *ecobee.APICall) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		EndpointKey.String(call.Endpoint),
+		httpRequestMethodKey.String(call.Method),
+	}
+
+	if call.Selection == nil || call.Selection.SelectionType == nil {
+		return attributes
+	}
+
+	attributes = append(attributes, SelectionTypeKey.String(*call.Selection.SelectionType))
+
+	if *call.Selection.SelectionType == "thermostats" && call.Selection.SelectionMatch != nil && *call.Selection.SelectionMatch != "" {
+		attributes = append(attributes, ThermostatIdentifiersKey.StringSlice(strings.Split(*call.Selection.SelectionMatch, ",")))
+	}
+
+	return attributes
+}
@@ -0,0 +1,268 @@
+package otelecobee_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/sherif-fanous/go-ecobee"
+	"github.com/sherif-fanous/go-ecobee/objects"
+	"github.com/sherif-fanous/go-ecobee/otelecobee"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		body           string
+		closeServer    bool
+		wantAttributes []attribute.KeyValue
+		wantMissing    []attribute.Key
+		wantSpanStatus codes.Code
+		wantErrorType  string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body:       `{"page":{"page":1,"totalPages":1,"pageSize":2,"total":2},"thermostatList":[],"status":{"code":0,"message":""}}`,
+			wantAttributes: []attribute.KeyValue{
+				otelecobee.EndpointKey.String("thermostat"),
+				otelecobee.SelectionTypeKey.String("thermostats"),
+				otelecobee.ThermostatIdentifiersKey.StringSlice([]string{"1", "2"}),
+				otelecobee.AttemptsKey.Int(1),
+				otelecobee.PageKey.Int(1),
+				otelecobee.StatusCodeKey.Int(0),
+				attribute.Int("http.response.status_code", http.StatusOK),
+			},
+			wantSpanStatus: codes.Unset,
+		},
+		{
+			name:       "ecobee status",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"status":{"code":2,"message":"Not authorized."}}`,
+			wantAttributes: []attribute.KeyValue{
+				otelecobee.EndpointKey.String("thermostat"),
+				otelecobee.StatusCodeKey.Int(2),
+				attribute.Int("http.response.status_code", http.StatusInternalServerError),
+			},
+			wantMissing:    []attribute.Key{otelecobee.PageKey},
+			wantSpanStatus: codes.Error,
+			wantErrorType:  "2",
+		},
+		{
+			name:        "transport error",
+			closeServer: true,
+			wantAttributes: []attribute.KeyValue{
+				otelecobee.EndpointKey.String("thermostat"),
+				otelecobee.SelectionTypeKey.String("thermostats"),
+			},
+			wantMissing:    []attribute.Key{otelecobee.StatusCodeKey, otelecobee.PageKey},
+			wantSpanStatus: codes.Error,
+			wantErrorType:  "transport",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			if test.closeServer {
+				server.Close()
+			}
+
+			spanExporter := tracetest.NewInMemoryExporter()
+			tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter))
+			metricReader := sdkmetric.NewManualReader()
+			meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+
+			client := ecobee.NewClient(
+				ecobee.WithAPIBaseURL(server.URL+"/"),
+				otelecobee.WithInstrumentation(
+					otelecobee.WithTracerProvider(tracerProvider),
+					otelecobee.WithMeterProvider(meterProvider),
+				),
+			)
+
+			_, _ = client.Thermostat(context.Background(), &objects.Selection{
+				SelectionType:  ecobee.String("thermostats"),
+				SelectionMatch: ecobee.String("1,2"),
+			}, nil)
+
+			spans := spanExporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("spans = %d, want 1", len(spans))
+			}
+
+			span := spans[0]
+
+			if span.Name != "ecobee thermostat" {
+				t.Errorf("span name = %q, want %q", span.Name, "ecobee thermostat")
+			}
+
+			if span.Status.Code != test.wantSpanStatus {
+				t.Errorf("span status = %v, want %v", span.Status.Code, test.wantSpanStatus)
+			}
+
+			spanAttributes := attribute.NewSet(span.Attributes...)
+
+			for _, want := range test.wantAttributes {
+				if got, ok := spanAttributes.Value(want.Key); !ok || got != want.Value {
+					t.Errorf("span attribute %s = %v, want %v", want.Key, got.Emit(), want.Value.Emit())
+				}
+			}
+
+			for _, key := range test.wantMissing {
+				if spanAttributes.HasValue(key) {
+					t.Errorf("span attribute %s is set, want it unset", key)
+				}
+			}
+
+			resourceMetrics := metricdata.ResourceMetrics{}
+			if err := metricReader.Collect(context.Background(), &resourceMetrics); err != nil {
+				t.Fatalf("Collect() error = %v", err)
+			}
+
+			metrics := map[string]metricdata.Metrics{}
+
+			for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+				for _, m := range scopeMetrics.Metrics {
+					metrics[m.Name] = m
+				}
+			}
+
+			histogram, ok := metrics["ecobee.client.request.duration"].Data.(metricdata.Histogram[float64])
+			if !ok || len(histogram.DataPoints) != 1 {
+				t.Fatalf("ecobee.client.request.duration = %+v, want a histogram with 1 data point", metrics["ecobee.client.request.duration"])
+			}
+
+			endpointAttributes := attribute.NewSet(otelecobee.EndpointKey.String("thermostat"))
+
+			if dataPoint := histogram.DataPoints[0]; dataPoint.Count != 1 || !dataPoint.Attributes.Equals(&endpointAttributes) {
+				t.Errorf("ecobee.client.request.duration data point = count %d attributes %v, want count 1 attributes ecobee.endpoint=thermostat", dataPoint.Count, dataPoint.Attributes.ToSlice())
+			}
+
+			errorMetrics, recorded := metrics["ecobee.client.request.errors"]
+
+			if test.wantErrorType == "" {
+				if recorded {
+					t.Errorf("ecobee.client.request.errors = %+v, want none", errorMetrics)
+				}
+
+				return
+			}
+
+			sum, ok := errorMetrics.Data.(metricdata.Sum[int64])
+			if !ok || len(sum.DataPoints) != 1 {
+				t.Fatalf("ecobee.client.request.errors = %+v, want a sum with 1 data point", errorMetrics)
+			}
+
+			wantAttributes := attribute.NewSet(otelecobee.EndpointKey.String("thermostat"), otelecobee.ErrorTypeKey.String(test.wantErrorType))
+
+			if dataPoint := sum.DataPoints[0]; dataPoint.Value != 1 || !dataPoint.Attributes.Equals(&wantAttributes) {
+				t.Errorf("ecobee.client.request.errors data point = value %d attributes %v, want value 1 attributes %v", dataPoint.Value, dataPoint.Attributes.ToSlice(), wantAttributes.ToSlice())
+			}
+		})
+	}
+}
+
+func TestMiddlewareRetries(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts++; attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"thermostatList":[],"status":{"code":0,"message":""}}`))
+	}))
+	defer server.Close()
+
+	spanExporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter))
+	metricReader := sdkmetric.NewManualReader()
+
+	client := ecobee.NewClient(
+		ecobee.WithAPIBaseURL(server.URL+"/"),
+		ecobee.WithRetryPolicy(&ecobee.RetryPolicy{MaxAttempts: 2}),
+		otelecobee.WithInstrumentation(
+			otelecobee.WithTracerProvider(tracerProvider),
+			otelecobee.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))),
+		),
+	)
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+
+	if _, err := client.Thermostat(ctx, &objects.Selection{SelectionType: ecobee.String("registered")}, nil); err != nil {
+		t.Fatalf("Thermostat() error = %v", err)
+	}
+
+	parent.End()
+
+	spans := spanExporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+
+	span := spans[0]
+
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span parent = %s, want %s", span.Parent.SpanID(), parent.SpanContext().SpanID())
+	}
+
+	if span.Status.Code != codes.Unset {
+		t.Errorf("span status = %v, want %v", span.Status.Code, codes.Unset)
+	}
+
+	spanAttributes := attribute.NewSet(span.Attributes...)
+
+	if got, _ := spanAttributes.Value(otelecobee.AttemptsKey); got.AsInt64() != 2 {
+		t.Errorf("span %s = %d, want 2", otelecobee.AttemptsKey, got.AsInt64())
+	}
+
+	wantEvents := []attribute.Set{
+		attribute.NewSet(otelecobee.AttemptKey.Int(1), attribute.Int("http.response.status_code", http.StatusServiceUnavailable), otelecobee.ErrorTypeKey.String("503")),
+		attribute.NewSet(otelecobee.AttemptKey.Int(2), attribute.Int("http.response.status_code", http.StatusOK), otelecobee.StatusCodeKey.Int(0)),
+	}
+
+	if len(span.Events) != len(wantEvents) {
+		t.Fatalf("span events = %d, want %d", len(span.Events), len(wantEvents))
+	}
+
+	for i, event := range span.Events {
+		if got := attribute.NewSet(event.Attributes...); event.Name != "ecobee.attempt" || !got.Equals(&wantEvents[i]) {
+			t.Errorf("span event %d = %s %v, want ecobee.attempt %v", i, event.Name, got.ToSlice(), wantEvents[i].ToSlice())
+		}
+	}
+
+	resourceMetrics := metricdata.ResourceMetrics{}
+	if err := metricReader.Collect(context.Background(), &resourceMetrics); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Count != 1 {
+					t.Errorf("%s = %+v, want 1 call", m.Name, data)
+				}
+			default:
+				t.Errorf("%s = %+v, want no errors", m.Name, data)
+			}
+		}
+	}
+}
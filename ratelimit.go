@@ -0,0 +1,205 @@
+package ecobee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request would exceed the rate limit
+// configured with WithRateLimit and the RateLimitPolicy fails fast.
+var ErrRateLimited = errors.New("rate limited")
+
+// A RateLimit specifies a token bucket. The bucket holds up to Burst tokens
+// and is refilled with one token every Every. Each request consumes one
+// token.
+type RateLimit struct {
+	// The interval at which a token is added to the bucket.
+	Every time.Duration
+	// The maximum number of tokens in the bucket, i.e. the number of requests
+	// that can be made back to back.
+	Burst int
+}
+
+// A RateLimitPolicy specifies the rate limits enforced by a Client before
+// sending requests.
+type RateLimitPolicy struct {
+	// The rate limit shared by all requests made by the Client. If nil,
+	// requests are only limited per endpoint.
+	Client *RateLimit
+	// The rate limits of individual endpoints keyed by endpoint name (e.g.
+	// thermostat, thermostatSummary, runtimeReport). Endpoints without an
+	// entry are only limited by Client.
+	Endpoints map[string]RateLimit
+	// Whether to return an error satisfying errors.Is(err, ErrRateLimited)
+	// instead of waiting for a token to become available.
+	FailFast bool
+}
+
+// DefaultRateLimitPolicy returns a RateLimitPolicy tuned to the ecobee polling
+// guidance. ecobee asks applications not to poll a thermostat more often than
+// every 3 minutes, and to use thermostatSummary to detect changes before
+// requesting thermostat data.
+//
+//   - thermostatSummary: bursts of 4, then one request every 15 seconds.
+//   - thermostat: bursts of 6, then one request every 30 seconds.
+//   - runtimeReport and meterReport: bursts of 5, then one request every minute.
+//
+// Waits are not failed fast.
+func DefaultRateLimitPolicy() *RateLimitPolicy {
+	return &RateLimitPolicy{
+		Endpoints: map[string]RateLimit{
+			thermostatSummaryEndpoint: {Every: 15 * time.Second, Burst: 4},
+			thermostatEndpoint:        {Every: 30 * time.Second, Burst: 6},
+			runtimeReportEndpoint:     {Every: time.Minute, Burst: 5},
+			meterReportEndpoint:       {Every: time.Minute, Burst: 5},
+		},
+	}
+}
+
+// WithRateLimit returns a function that initializes a Client with a rate
+// limit policy. Every attempt of a request, including retries, consumes a
+// token from the Client bucket and from its endpoint bucket.
+func WithRateLimit(rateLimitPolicy *RateLimitPolicy) func(*Client) {
+	return func(c *Client) {
+		c.rateLimiter = newRateLimiter(rateLimitPolicy)
+	}
+}
+
+type rateLimiter struct {
+	client    *tokenBucket
+	endpoints map[string]*tokenBucket
+	failFast  bool
+}
+
+func newRateLimiter(rateLimitPolicy *RateLimitPolicy) *rateLimiter {
+	r := &rateLimiter{
+		endpoints: make(map[string]*tokenBucket),
+		failFast:  rateLimitPolicy.FailFast,
+	}
+
+	if rateLimitPolicy.Client != nil {
+		r.client = newTokenBucket(*rateLimitPolicy.Client)
+	}
+
+	for endpoint, rateLimit := range rateLimitPolicy.Endpoints {
+		r.endpoints[endpoint] = newTokenBucket(rateLimit)
+	}
+
+	return r
+}
+
+// wait takes a token from the client bucket and from the endpoint's bucket.
+// If either is rejected or ctx is done, the tokens already taken are returned
+// so requests that are not sent do not drain the buckets.
+func (r *rateLimiter) wait(ctx context.Context, endpoint string) error {
+	reserved := []*tokenBucket{}
+
+	for _, bucket := range []*tokenBucket{r.client, r.endpoints[endpoint]} {
+		if bucket == nil {
+			continue
+		}
+
+		if err := bucket.wait(ctx, r.failFast); err != nil {
+			for _, reservedBucket := range reserved {
+				reservedBucket.cancel()
+			}
+
+			return err
+		}
+
+		reserved = append(reserved, bucket)
+	}
+
+	return nil
+}
+
+type tokenBucket struct {
+	every time.Duration
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rateLimit RateLimit) *tokenBucket {
+	burst := rateLimit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		every:  rateLimit.Every,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket, letting the number of tokens go
+// negative, and returns how long the caller must wait before using it. If
+// failFast is true and a wait is required no token is taken and ok is false.
+func (b *tokenBucket) reserve(failFast bool) (wait time.Duration, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.every <= 0 {
+		return 0, true
+	}
+
+	now := time.Now()
+
+	b.tokens += float64(now.Sub(b.last)) / float64(b.every)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return 0, true
+	}
+
+	if failFast {
+		return 0, false
+	}
+
+	wait = time.Duration((1 - b.tokens) * float64(b.every))
+	b.tokens--
+
+	return wait, true
+}
+
+// cancel returns a token taken by reserve that will not be used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+func (b *tokenBucket) wait(ctx context.Context, failFast bool) error {
+	wait, ok := b.reserve(failFast)
+	if !ok {
+		return ErrRateLimited
+	}
+
+	if wait == 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, wait); err != nil {
+		b.cancel()
+
+		return err
+	}
+
+	return nil
+}
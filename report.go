@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/sherif-fanous/go-ecobee/objects"
@@ -25,6 +26,16 @@ const (
 	MeterTypeEnergy MeterType = "energy"
 )
 
+// IsValid reports whether v is one of the supported MeterType values.
+func (v MeterType) IsValid() bool {
+	switch v {
+	case MeterTypeEnergy:
+		return true
+	}
+
+	return false
+}
+
 // An MeterReportParameters specifies the request parameters of the
 // MeterReport method.
 type MeterReportParameters struct {
@@ -40,11 +51,71 @@ type MeterReportParameters struct {
 	Meters []MeterType
 }
 
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *MeterReportParameters) validate() error {
+	v := newValidator(meterReportEndpoint)
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.reportDates(p.StartDate, p.StartInterval, p.EndDate, p.EndInterval)
+	v.required(len(p.Meters) != 0, "meters")
+
+	for _, meter := range p.Meters {
+		v.check(meter.IsValid(), "meters", "invalid value %q", meter)
+	}
+
+	return v.err()
+}
+
 // MeterReport retrieves the historical meter reading information for a
 // selection of thermostats.
 //
+// A selection of type thermostats matching more than 25 thermostats is split
+// into batches of 25 thermostats whose report lists are merged. If any batch
+// fails a *BatchError is returned.
+//
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/operations/get-meter-report.shtml
 func (c *Client) MeterReport(ctx context.Context, selection *objects.Selection, parameters *MeterReportParameters) (*MeterReportSuccessResponse, error) {
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	selections := splitSelection(selection)
+	if selections == nil {
+		return c.meterReport(ctx, selection, parameters)
+	}
+
+	responses := make([]*MeterReportSuccessResponse, len(selections))
+
+	if err := c.runBatches(ctx, meterReportEndpoint, selections, func(ctx context.Context, i int, selection *objects.Selection) error {
+		meterReportResponse, err := c.meterReport(ctx, selection, parameters)
+		responses[i] = meterReportResponse
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	meterReportResponse := MeterReportSuccessResponse{}
+	meterReportResponse.status = responses[0].status
+
+	for _, response := range responses {
+		meterReportResponse.reportList = append(meterReportResponse.reportList, response.reportList...)
+	}
+
+	return &meterReportResponse, nil
+}
+
+func (c *Client) meterReport(ctx context.Context, selection *objects.Selection, parameters *MeterReportParameters) (*MeterReportSuccessResponse, error) {
+	meters := make([]string, len(parameters.Meters))
+	for i, meter := range parameters.Meters {
+		meters[i] = string(meter)
+	}
+
 	data, err := json.Marshal(struct {
 		Selection     *objects.Selection `json:"selection,omitempty"`
 		StartDate     *string            `json:"startDate,omitempty"`
@@ -58,7 +129,7 @@ func (c *Client) MeterReport(ctx context.Context, selection *objects.Selection,
 		StartInterval: parameters.StartInterval,
 		EndDate:       String((*parameters.EndDate).Format("2006-01-02")),
 		EndInterval:   parameters.EndInterval,
-		Meters:        String(string(parameters.Meters[0])),
+		Meters:        String(strings.Join(meters, ",")),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", meterReportEndpoint, err)
@@ -68,7 +139,7 @@ func (c *Client) MeterReport(ctx context.Context, selection *objects.Selection,
 	queryParameters.Set("format", "json")
 	queryParameters.Set("body", string(data))
 
-	resp, err := c.get(ctx, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, meterReportEndpoint), queryParameters, nil)
+	resp, err := c.get(ctx, meterReportEndpoint, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, meterReportEndpoint), selection, queryParameters, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", meterReportEndpoint, err)
 	}
@@ -106,11 +177,63 @@ type RuntimeReportParameters struct {
 	IncludeSensor *bool
 }
 
+// validate returns a *ValidationError listing the missing or invalid
+// parameters of p.
+func (p *RuntimeReportParameters) validate() error {
+	v := newValidator(runtimeReportEndpoint)
+	if p == nil {
+		v.required(false, "parameters")
+
+		return v.err()
+	}
+
+	v.reportDates(p.StartDate, p.StartInterval, p.EndDate, p.EndInterval)
+	v.required(p.Columns != nil && *p.Columns != "", "columns")
+
+	return v.err()
+}
+
 // RuntimeReport retrieves the historical runtime report information for a
 // selection of thermostats.
 //
+// A selection of type thermostats matching more than 25 thermostats is split
+// into batches of 25 thermostats whose report and sensor lists are merged. If
+// any batch fails a *BatchError is returned.
+//
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/operations/get-runtime-report.shtml
 func (c *Client) RuntimeReport(ctx context.Context, selection *objects.Selection, parameters *RuntimeReportParameters) (*RuntimeReportSuccessResponse, error) {
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+
+	selections := splitSelection(selection)
+	if selections == nil {
+		return c.runtimeReport(ctx, selection, parameters)
+	}
+
+	responses := make([]*RuntimeReportSuccessResponse, len(selections))
+
+	if err := c.runBatches(ctx, runtimeReportEndpoint, selections, func(ctx context.Context, i int, selection *objects.Selection) error {
+		runtimeReportResponse, err := c.runtimeReport(ctx, selection, parameters)
+		responses[i] = runtimeReportResponse
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	runtimeReportResponse := RuntimeReportSuccessResponse{}
+	runtimeReportResponse.status = responses[0].status
+
+	for _, response := range responses {
+		runtimeReportResponse.reportList = append(runtimeReportResponse.reportList, response.reportList...)
+		runtimeReportResponse.sensorList = append(runtimeReportResponse.sensorList, response.sensorList...)
+	}
+
+	return &runtimeReportResponse, nil
+}
+
+func (c *Client) runtimeReport(ctx context.Context, selection *objects.Selection, parameters *RuntimeReportParameters) (*RuntimeReportSuccessResponse, error) {
 	data, err := json.Marshal(struct {
 		Selection     *objects.Selection `json:"selection,omitempty"`
 		StartDate     *string            `json:"startDate,omitempty"`
@@ -136,7 +259,7 @@ func (c *Client) RuntimeReport(ctx context.Context, selection *objects.Selection
 	queryParameters.Set("format", "json")
 	queryParameters.Set("body", string(data))
 
-	resp, err := c.get(ctx, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, runtimeReportEndpoint), queryParameters, nil)
+	resp, err := c.get(ctx, runtimeReportEndpoint, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, runtimeReportEndpoint), selection, queryParameters, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", runtimeReportEndpoint, err)
 	}
@@ -1,24 +1,111 @@
 package ecobee
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/sherif-fanous/go-ecobee/objects"
 	"golang.org/x/net/context/ctxhttp"
 )
 
-func (c *Client) get(ctx context.Context, endpoint string, queryParameters url.Values, headers map[string][]string) (*http.Response, error) {
-	return c.sendRequest(ctx, http.MethodGet, endpoint, queryParameters, headers, nil)
+func (c *Client) get(ctx context.Context, endpoint string, endpointURL string, selection *objects.Selection, queryParameters url.Values, headers map[string][]string) (*http.Response, error) {
+	return c.sendRequest(ctx, http.MethodGet, endpoint, endpointURL, selection, queryParameters, headers, nil)
 }
 
-func (c *Client) post(ctx context.Context, endpoint string, queryParameters url.Values, headers map[string][]string, body io.Reader) (*http.Response, error) {
-	return c.sendRequest(ctx, http.MethodPost, endpoint, queryParameters, headers, body)
+func (c *Client) post(ctx context.Context, endpoint string, endpointURL string, selection *objects.Selection, queryParameters url.Values, headers map[string][]string, body io.Reader) (*http.Response, error) {
+	return c.sendRequest(ctx, http.MethodPost, endpoint, endpointURL, selection, queryParameters, headers, body)
 }
 
-func (c *Client) sendRequest(ctx context.Context, method string, endpoint string, queryParameters url.Values, headers map[string][]string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(method, endpoint, body)
+func (c *Client) sendRequest(ctx context.Context, method string, endpoint string, endpointURL string, selection *objects.Selection, queryParameters url.Values, headers map[string][]string, body io.Reader) (*http.Response, error) {
+	var bodyData []byte
+
+	if body != nil {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+
+		bodyData = data
+	}
+
+	call := &APICall{
+		Endpoint:  endpoint,
+		Selection: selection,
+		Method:    method,
+	}
+
+	return c.callChain(func(ctx context.Context, call *APICall) (*http.Response, error) {
+		return c.sendAttempts(ctx, call, endpointURL, queryParameters, headers, bodyData)
+	})(ctx, call)
+}
+
+// sendAttempts sends the request of call through the middleware chain,
+// retrying it according to the Client's RetryPolicy.
+func (c *Client) sendAttempts(ctx context.Context, call *APICall, endpointURL string, queryParameters url.Values, headers map[string][]string, bodyData []byte) (*http.Response, error) {
+	retryPolicy := c.retryPolicy
+	if retryPolicy == nil || !retryPolicy.retryMethod(call.Method) {
+		retryPolicy = &RetryPolicy{MaxAttempts: 1}
+	}
+
+	roundTrip := c.roundTrip()
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.wait(ctx, call.Endpoint); err != nil {
+				return nil, err
+			}
+		}
+
+		req, err := c.newRequest(call.Method, endpointURL, queryParameters, headers, bodyData)
+		if err != nil {
+			return nil, err
+		}
+
+		call.Attempts = attempt
+
+		resp, err := roundTrip(ctx, &APIRequest{
+			Endpoint:    call.Endpoint,
+			Selection:   call.Selection,
+			Attempt:     attempt,
+			HTTPRequest: req,
+		})
+
+		if attempt >= retryPolicy.MaxAttempts || !retryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := retryPolicy.backoff(attempt)
+
+		if retryPolicy.MaxElapsedTime > 0 && time.Since(start)+wait > retryPolicy.MaxElapsedTime {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) newRequest(method string, endpointURL string, queryParameters url.Values, headers map[string][]string, bodyData []byte) (*http.Request, error) {
+	var body io.Reader
+
+	if bodyData != nil {
+		body = bytes.NewReader(bodyData)
+	}
+
+	req, err := http.NewRequest(method, endpointURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -33,17 +120,18 @@ func (c *Client) sendRequest(ctx context.Context, method string, endpoint string
 		req.Header[k] = v
 	}
 
-	resp, err := c.doRequest(ctx, req)
-	if err != nil {
-		return resp, err
-	}
-
-	return resp, nil
+	return req, nil
 }
 
 func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
 	if err != nil {
+		var urlError *url.Error
+
+		if errors.As(err, &urlError) {
+			urlError.URL = redactURL(req.URL)
+		}
+
 		return resp, err
 	}
 
@@ -7,6 +7,9 @@ import (
 	"github.com/sherif-fanous/go-ecobee/objects"
 	"io"
 	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
 )
 
 type apiStatusResponse struct {
@@ -54,7 +57,7 @@ func (a *APIStatusResponse) Status() *objects.Status {
 }
 
 type authorizationErrorResponse struct {
-	errorType        string
+	errorType        AuthorizationErrorType
 	errorDescription string
 	errorURI         string
 }
@@ -68,9 +71,9 @@ type AuthorizationErrorResponse struct {
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (e *AuthorizationErrorResponse) UnmarshalJSON(data []byte) error {
 	temp := struct {
-		ErrorType        string `json:"error"`
-		ErrorDescription string `json:"error_description"`
-		ErrorURI         string `json:"error_uri"`
+		ErrorType        AuthorizationErrorType `json:"error"`
+		ErrorDescription string                 `json:"error_description"`
+		ErrorURI         string                 `json:"error_uri"`
 	}{}
 
 	if err := json.Unmarshal(data, &temp); err != nil {
@@ -85,7 +88,7 @@ func (e *AuthorizationErrorResponse) UnmarshalJSON(data []byte) error {
 }
 
 // ErrorType returns the response's error type.
-func (e *AuthorizationErrorResponse) ErrorType() string {
+func (e *AuthorizationErrorResponse) ErrorType() AuthorizationErrorType {
 	return e.errorType
 }
 
@@ -228,7 +231,8 @@ type PINAuthorizationSuccessResponse struct {
 }
 
 // String implements the fmt.Stringer interface. It returns a string
-// representing an indented JSON encoding of the response.
+// representing an indented JSON encoding of the response. The authorization
+// token and the PIN are redacted.
 func (a *PINAuthorizationSuccessResponse) String() string {
 	temp := struct {
 		AuthorizationToken string `json:""`
@@ -237,9 +241,9 @@ func (a *PINAuthorizationSuccessResponse) String() string {
 		PollingInterval    int    `json:""`
 		Scope              Scope  `json:""`
 	}{
-		AuthorizationToken: a.authorizationToken,
+		AuthorizationToken: redactString(a.authorizationToken),
 		ExpiresIn:          a.expiresIn,
-		PIN:                a.pin,
+		PIN:                redactString(a.pin),
 		PollingInterval:    a.pollingInterval,
 		Scope:              a.scope,
 	}
@@ -377,6 +381,9 @@ type thermostatSuccessResponse struct {
 // ecobee server while retrieving thermostat data.
 type ThermostatSuccessResponse struct {
 	thermostatSuccessResponse
+	// batchPages holds the page returned for every batch of a split
+	// selection, or nil for the batches that failed.
+	batchPages []*objects.Page
 }
 
 // String implements the fmt.Stringer interface. It returns a string
@@ -521,6 +528,7 @@ type tokensSuccessResponse struct {
 	expiresIn    int
 	refreshToken string
 	scope        Scope
+	issuedAt     time.Time
 }
 
 // TokensSuccessResponse describes the success response returned by the ecobee
@@ -530,7 +538,8 @@ type TokensSuccessResponse struct {
 }
 
 // String implements the fmt.Stringer interface. It returns a string
-// representing an indented JSON encoding of the response.
+// representing an indented JSON encoding of the response. The access and
+// refresh tokens are redacted.
 func (t *TokensSuccessResponse) String() string {
 	temp := struct {
 		AccessToken  string `json:"access_token"`
@@ -539,10 +548,10 @@ func (t *TokensSuccessResponse) String() string {
 		RefreshToken string `json:"refresh_token"`
 		Scope        Scope  `json:"scope"`
 	}{
-		AccessToken:  t.accessToken,
+		AccessToken:  redactString(t.accessToken),
 		TokenType:    t.tokenType,
 		ExpiresIn:    t.expiresIn,
-		RefreshToken: t.refreshToken,
+		RefreshToken: redactString(t.refreshToken),
 		Scope:        t.scope,
 	}
 
@@ -599,28 +608,61 @@ func (t *tokensSuccessResponse) Scope() Scope {
 	return t.scope
 }
 
+// OAuth2Token returns the response's tokens as an OAuth2 token. The token's
+// expiry is computed relative to the time the tokens were requested.
+func (t *TokensSuccessResponse) OAuth2Token() *oauth2.Token {
+	issuedAt := t.issuedAt
+	if issuedAt.IsZero() {
+		issuedAt = time.Now().UTC()
+	}
+
+	return &oauth2.Token{
+		TokenType:    t.tokenType,
+		AccessToken:  t.accessToken,
+		Expiry:       issuedAt.Add(time.Second * time.Duration(t.expiresIn)),
+		RefreshToken: t.refreshToken,
+	}
+}
+
 func processAPIResponse(endpoint string, resp *http.Response, responseObject interface{}) error {
 	if resp.StatusCode != http.StatusOK {
 		errorResponse := APIStatusResponse{}
 
 		switch err := json.NewDecoder(resp.Body).Decode(&errorResponse); {
 		case err == io.EOF:
-			return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status)
+			return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status)
 		case err != nil:
 			var e *json.SyntaxError
 
 			if errors.As(err, &e) {
-				return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status)
+				return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status)
 			}
 
-			return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status, err)
+			return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status, err)
+		}
+
+		if errorResponse.status == nil || errorResponse.status.Code == nil {
+			return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status)
 		}
 
-		return &APIError{errorString: fmt.Sprintf("%s: %s %q: %s: code %d: %s", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status, *errorResponse.status.Code, *errorResponse.status.Message)}
+		apiError := &APIError{
+			endpoint:       endpoint,
+			method:         resp.Request.Method,
+			url:            redactURL(resp.Request.URL),
+			httpStatus:     resp.Status,
+			httpStatusCode: resp.StatusCode,
+			statusCode:     StatusCode(*errorResponse.status.Code),
+		}
+
+		if errorResponse.status.Message != nil {
+			apiError.statusMessage = *errorResponse.status.Message
+		}
+
+		return apiError
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(responseObject); err != nil {
-		return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status, err)
+		return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status, err)
 	}
 
 	return nil
@@ -632,22 +674,31 @@ func processAuthorizationResponse(endpoint string, resp *http.Response, response
 
 		switch err := json.NewDecoder(resp.Body).Decode(&errorResponse); {
 		case err == io.EOF:
-			return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status)
+			return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status)
 		case err != nil:
 			var e *json.SyntaxError
 
 			if errors.As(err, &e) {
-				return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status)
+				return fmt.Errorf("%s: %s %q: %s", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status)
 			}
 
-			return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status, err)
+			return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status, err)
 		}
 
-		return &AuthorizationError{errorString: fmt.Sprintf("%s: %s %q: %s: %s: %s: %s", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status, errorResponse.errorType, errorResponse.errorDescription, errorResponse.errorURI)}
+		return &AuthorizationError{
+			endpoint:         endpoint,
+			method:           resp.Request.Method,
+			url:              redactURL(resp.Request.URL),
+			httpStatus:       resp.Status,
+			httpStatusCode:   resp.StatusCode,
+			errorType:        errorResponse.errorType,
+			errorDescription: errorResponse.errorDescription,
+			errorURI:         errorResponse.errorURI,
+		}
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(responseObject); err != nil {
-		return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, resp.Request.URL.String(), resp.Status, err)
+		return fmt.Errorf("%s: %s %q: %s: %w", endpoint, resp.Request.Method, redactURL(resp.Request.URL), resp.Status, err)
 	}
 
 	return nil
@@ -0,0 +1,150 @@
+package ecobee
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// A RetryPolicy specifies how a Client retries requests that failed because
+// of a transient error. A request is considered to have failed transiently if
+// the connection failed, the ecobee server responded with HTTP status 429 or
+// 5xx without an ecobee status, or with ecobee status code 3 (processing
+// error). Errors that would fail again, such as a failed token refresh or a
+// TokenStore error, are not retried.
+//
+// Waits between attempts grow exponentially from InitialInterval by
+// Multiplier up to MaxInterval, and are randomized by ±50% to avoid
+// synchronized retries.
+type RetryPolicy struct {
+	// The maximum number of attempts, including the first one. A value lower
+	// than 2 disables retrying.
+	MaxAttempts int
+	// The maximum time spent on a request across all attempts and waits. Zero
+	// means no limit besides MaxAttempts.
+	MaxElapsedTime time.Duration
+	// The wait before the first retry.
+	InitialInterval time.Duration
+	// The upper bound of the wait between attempts.
+	MaxInterval time.Duration
+	// The factor the wait is multiplied by after every attempt.
+	Multiplier float64
+	// Whether to retry POST requests. POST requests are not idempotent so a
+	// request that timed out may have been applied by the server. ecobee
+	// invalidates the previous refresh token as soon as a new one is issued,
+	// so enabling this can lock an application out if a token refresh is
+	// retried after its response was lost.
+	RetryPOST bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 4 attempts within 1
+// minute, waiting 500ms before the first retry and at most 10s between
+// attempts. POST requests are not retried.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     4,
+		MaxElapsedTime:  time.Minute,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+	}
+}
+
+// WithRetryPolicy returns a function that initializes a Client with a retry
+// policy. By default a Client makes exactly one attempt per request.
+func WithRetryPolicy(retryPolicy *RetryPolicy) func(*Client) {
+	return func(c *Client) {
+		c.retryPolicy = retryPolicy
+	}
+}
+
+func (r *RetryPolicy) retryMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return r.RetryPOST
+	}
+
+	return false
+}
+
+// backoff returns the randomized wait following the specified attempt
+// (starting at 1).
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	interval := float64(r.InitialInterval) * math.Pow(multiplier, float64(attempt-1))
+	if r.MaxInterval > 0 && interval > float64(r.MaxInterval) {
+		interval = float64(r.MaxInterval)
+	}
+
+	return time.Duration(interval * (0.5 + rand.Float64()))
+}
+
+// retryable reports whether an attempt that returned resp and err failed
+// transiently. When resp is retained its body is replaced so it can still be
+// read by the caller.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		return transientError(err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode < http.StatusInternalServerError:
+		return false
+	}
+
+	status := ResponseStatus(resp)
+
+	return status == nil || status.Code == nil || StatusCode(*status.Code) == StatusCodeProcessingError
+}
+
+// transientError reports whether err is a network failure, as opposed to an
+// error raised before the request was sent, such as a failed token refresh.
+func transientError(err error) bool {
+	var urlError *url.Error
+
+	if errors.As(err, &urlError) {
+		err = urlError.Err
+	}
+
+	// A nested *url.Error is the failure of a request made before the
+	// request could be sent, i.e. a token refresh, which must not be
+	// repeated.
+	if errors.As(err, &urlError) {
+		return false
+	}
+
+	var netError net.Error
+
+	return errors.As(err, &netError) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,263 @@
+package ecobee
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+	"golang.org/x/oauth2"
+)
+
+const thermostatResponseBody = `{"page":{"page":1,"totalPages":1,"pageSize":1,"total":1},"thermostatList":[{"identifier":"1"}],"status":{"code":0,"message":""}}`
+
+// newTestClient returns a Client sending its requests to a test server
+// serving handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc, optionalParameters ...clientOptionalParameters) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewClient(append([]clientOptionalParameters{WithAPIBaseURL(server.URL + "/")}, optionalParameters...)...)
+}
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      2,
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		body           string
+		wantAttempts   int32
+		wantStatusCode StatusCode
+	}{
+		{
+			name:         "HTTP 500 with processing error is retried",
+			statusCode:   http.StatusInternalServerError,
+			body:         `{"status":{"code":3,"message":"Processing error."}}`,
+			wantAttempts: 2,
+		},
+		{
+			name:         "HTTP 503 without ecobee status is retried",
+			statusCode:   http.StatusServiceUnavailable,
+			wantAttempts: 2,
+		},
+		{
+			name:         "HTTP 429 is retried",
+			statusCode:   http.StatusTooManyRequests,
+			wantAttempts: 2,
+		},
+		{
+			name:           "HTTP 500 with another ecobee status is not retried",
+			statusCode:     http.StatusInternalServerError,
+			body:           `{"status":{"code":2,"message":"Not authorized."}}`,
+			wantAttempts:   1,
+			wantStatusCode: StatusCodeNotAuthorized,
+		},
+		{
+			name:           "HTTP 400 is not retried",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"status":{"code":9,"message":"Invalid selection."}}`,
+			wantAttempts:   1,
+			wantStatusCode: StatusCodeInvalidSelection,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := atomic.Int32{}
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.WriteHeader(test.statusCode)
+					_, _ = w.Write([]byte(test.body))
+
+					return
+				}
+
+				_, _ = w.Write([]byte(thermostatResponseBody))
+			}, WithRetryPolicy(testRetryPolicy()))
+
+			_, err := client.Thermostat(context.Background(), &objects.Selection{SelectionType: String("registered")}, nil)
+
+			if got := attempts.Load(); got != test.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, test.wantAttempts)
+			}
+
+			if test.wantAttempts > 1 {
+				if err != nil {
+					t.Fatalf("Thermostat() error = %v", err)
+				}
+
+				return
+			}
+
+			apiError := &APIError{}
+			if !errors.As(err, &apiError) {
+				t.Fatalf("Thermostat() error = %v, want an *APIError", err)
+			}
+
+			if apiError.StatusCode() != test.wantStatusCode {
+				t.Errorf("StatusCode() = %d, want %d", apiError.StatusCode(), test.wantStatusCode)
+			}
+		})
+	}
+}
+
+func TestRetryAuthorizationError(t *testing.T) {
+	refreshes, thermostatRequests := atomic.Int32{}, atomic.Int32{}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/"+tokenEndpoint) {
+			refreshes.Add(1)
+
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"The refresh token has expired."}`))
+
+			return
+		}
+
+		thermostatRequests.Add(1)
+
+		_, _ = w.Write([]byte(thermostatResponseBody))
+	}, WithRetryPolicy(testRetryPolicy()), WithToken("applicationKey", &oauth2.Token{
+		AccessToken:  "accessToken",
+		RefreshToken: "refreshToken",
+		Expiry:       time.Now().Add(-time.Hour),
+	}))
+
+	_, err := client.Thermostat(context.Background(), &objects.Selection{SelectionType: String("registered")}, nil)
+
+	authorizationError := &AuthorizationError{}
+	if !errors.As(err, &authorizationError) {
+		t.Fatalf("Thermostat() error = %v, want an *AuthorizationError", err)
+	}
+
+	if got := refreshes.Load(); got != 1 {
+		t.Errorf("refreshes = %d, want 1", got)
+	}
+
+	if got := thermostatRequests.Load(); got != 0 {
+		t.Errorf("thermostat requests = %d, want 0", got)
+	}
+}
+
+func TestRetryContextCanceledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := atomic.Int32{}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetryPolicy(&RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: time.Hour,
+		MaxInterval:     time.Hour,
+	}))
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+
+	_, err := client.Thermostat(ctx, &objects.Selection{SelectionType: String("registered")}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Thermostat() error = %v, want %v", err, context.Canceled)
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Thermostat() returned after %s, want it to return when ctx is canceled", elapsed)
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestRetryErrors(t *testing.T) {
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	refreshServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hijacker, _ := w.(http.Hijacker)
+		conn, _, _ := hijacker.Hijack()
+		_ = conn.Close()
+	}))
+	defer refreshServer.Close()
+
+	tests := []struct {
+		name               string
+		optionalParameters []clientOptionalParameters
+		wantAttempts       int
+		wantErr            error
+	}{
+		{
+			name:               "connection refused is retried",
+			optionalParameters: []clientOptionalParameters{WithAPIBaseURL(closedServer.URL + "/")},
+			wantAttempts:       3,
+		},
+		{
+			name: "missing refresh token is not retried",
+			optionalParameters: []clientOptionalParameters{
+				WithAPIBaseURL(closedServer.URL + "/"),
+				WithToken("applicationKey", &oauth2.Token{AccessToken: "accessToken", Expiry: time.Now().Add(-time.Hour)}),
+			},
+			wantAttempts: 1,
+			wantErr:      ErrNoRefreshToken,
+		},
+		{
+			name: "failed token refresh is not retried",
+			optionalParameters: []clientOptionalParameters{
+				WithAPIBaseURL(refreshServer.URL + "/"),
+				WithToken("applicationKey", &oauth2.Token{AccessToken: "accessToken", RefreshToken: "refreshToken", Expiry: time.Now().Add(-time.Hour)}),
+			},
+			wantAttempts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+
+			counting := func(next RoundTripFunc) RoundTripFunc {
+				return func(ctx context.Context, req *APIRequest) (*http.Response, error) {
+					if req.Endpoint == thermostatEndpoint {
+						attempts++
+					}
+
+					return next(ctx, req)
+				}
+			}
+
+			client := NewClient(append(test.optionalParameters, WithRetryPolicy(testRetryPolicy()), WithMiddleware(counting))...)
+
+			_, err := client.Thermostat(context.Background(), &objects.Selection{SelectionType: String("registered")}, nil)
+			if err == nil {
+				t.Fatal("Thermostat() error = nil, want an error")
+			}
+
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("Thermostat() error = %v, want %v", err, test.wantErr)
+			}
+
+			if attempts != test.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, test.wantAttempts)
+			}
+		})
+	}
+}
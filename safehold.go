@@ -0,0 +1,339 @@
+package ecobee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+// A SafeHoldMode specifies how SafeSetHold and SafeCreateVacation handle
+// setpoints outside the thermostat's limits.
+type SafeHoldMode int
+
+// Supported SafeHoldMode values.
+const (
+	// Out of range and too close setpoints are adjusted to the nearest
+	// allowed value.
+	SafeHoldModeClamp SafeHoldMode = iota
+	// Out of range and too close setpoints are rejected with a
+	// *ValidationError and no request is sent.
+	SafeHoldModeReject
+)
+
+// A SafeHoldOptions specifies how SafeSetHold and SafeCreateVacation guard
+// setpoints.
+type SafeHoldOptions struct {
+	// How out of range and too close setpoints are handled.
+	Mode SafeHoldMode
+	// The limits to guard setpoints against. If nil, the Runtime and Settings
+	// of the thermostats matching the selection are retrieved and the most
+	// restrictive limits of all of them are used.
+	Limits *SetpointLimits
+}
+
+// A SetpointLimits specifies the setpoints a thermostat accepts without
+// adjusting them.
+type SetpointLimits struct {
+	// The minimum heat setpoint.
+	HeatMin objects.Temperature
+	// The maximum heat setpoint.
+	HeatMax objects.Temperature
+	// The minimum cool setpoint.
+	CoolMin objects.Temperature
+	// The maximum cool setpoint.
+	CoolMax objects.Temperature
+	// The minimum difference between the cool and heat setpoints.
+	HeatCoolMinDelta objects.TemperatureDelta
+}
+
+// NewSetpointLimits returns the setpoint limits of a thermostat. The heat and
+// cool ranges are read from Runtime.DesiredHeatRange and
+// Runtime.DesiredCoolRange, which account for the running program and active
+// events, falling back to the firmware limits of Settings. The minimum
+// difference is read from Settings.HeatCoolMinDelta.
+func NewSetpointLimits(runtime *objects.Runtime, settings *objects.Settings) (*SetpointLimits, error) {
+	if runtime == nil {
+		runtime = &objects.Runtime{}
+	}
+
+	if settings == nil {
+		settings = &objects.Settings{}
+	}
+
+	limits := SetpointLimits{}
+	ok := false
+
+	if limits.HeatMin, limits.HeatMax, ok = runtime.DesiredHeatRangeValue(); !ok {
+		heatMin, minOK := settings.HeatMinTempValue()
+		heatMax, maxOK := settings.HeatMaxTempValue()
+		if !minOK || !maxOK {
+			return nil, errors.New("setpoint limits: missing runtime desiredHeatRange and settings heatMinTemp/heatMaxTemp")
+		}
+
+		limits.HeatMin, limits.HeatMax = heatMin, heatMax
+	}
+
+	if limits.CoolMin, limits.CoolMax, ok = runtime.DesiredCoolRangeValue(); !ok {
+		coolMin, minOK := settings.CoolMinTempValue()
+		coolMax, maxOK := settings.CoolMaxTempValue()
+		if !minOK || !maxOK {
+			return nil, errors.New("setpoint limits: missing runtime desiredCoolRange and settings coolMinTemp/coolMaxTemp")
+		}
+
+		limits.CoolMin, limits.CoolMax = coolMin, coolMax
+	}
+
+	if limits.HeatCoolMinDelta, ok = settings.HeatCoolMinDeltaValue(); !ok {
+		return nil, errors.New("setpoint limits: missing settings heatCoolMinDelta")
+	}
+
+	return &limits, nil
+}
+
+// restrict narrows l to the limits satisfying both l and other. It returns an
+// error if the intersection allows no heat or no cool setpoint.
+func (l *SetpointLimits) restrict(other *SetpointLimits) error {
+	if other.HeatMin > l.HeatMin {
+		l.HeatMin = other.HeatMin
+	}
+
+	if other.HeatMax < l.HeatMax {
+		l.HeatMax = other.HeatMax
+	}
+
+	if other.CoolMin > l.CoolMin {
+		l.CoolMin = other.CoolMin
+	}
+
+	if other.CoolMax < l.CoolMax {
+		l.CoolMax = other.CoolMax
+	}
+
+	if other.HeatCoolMinDelta > l.HeatCoolMinDelta {
+		l.HeatCoolMinDelta = other.HeatCoolMinDelta
+	}
+
+	return l.check()
+}
+
+// check returns an error if l allows no heat or no cool setpoint.
+func (l *SetpointLimits) check() error {
+	if l.HeatMin > l.HeatMax {
+		return fmt.Errorf("setpoint limits: no heat setpoint allowed: minimum %s is above maximum %s", l.HeatMin, l.HeatMax)
+	}
+
+	if l.CoolMin > l.CoolMax {
+		return fmt.Errorf("setpoint limits: no cool setpoint allowed: minimum %s is above maximum %s", l.CoolMin, l.CoolMax)
+	}
+
+	return nil
+}
+
+// A SetpointAdjustment describes a setpoint adjusted by SafeSetHold or
+// SafeCreateVacation.
+type SetpointAdjustment struct {
+	// The name of the adjusted parameter (e.g. heatHoldTemp).
+	Field string
+	// The setpoint requested by the caller, before any adjustment.
+	Requested objects.Temperature
+	// The setpoint sent to the thermostat.
+	Adjusted objects.Temperature
+	// Why the setpoint was adjusted.
+	Reason string
+}
+
+// guard clamps or rejects the heat and cool setpoints according to mode. Nil
+// setpoints are left as is. It returns the adjusted setpoints and the
+// adjustments made.
+func (l *SetpointLimits) guard(name string, mode SafeHoldMode, heat *int, cool *int) (*int, *int, []SetpointAdjustment, error) {
+	v := newValidator(name)
+	adjustments := []SetpointAdjustment{}
+
+	// adjust records that field was adjusted to adjusted. A field adjusted
+	// more than once is reported once, with the setpoint originally requested
+	// and the final adjusted setpoint.
+	adjust := func(field string, setpoint *int, adjusted objects.Temperature, reason string) *int {
+		v.check(mode != SafeHoldModeReject, field, "%s", reason)
+
+		for i := range adjustments {
+			if adjustments[i].Field == field {
+				adjustments[i].Adjusted = adjusted
+				adjustments[i].Reason += "; " + reason
+
+				return Int(adjusted.Tenths())
+			}
+		}
+
+		adjustments = append(adjustments, SetpointAdjustment{
+			Field:     field,
+			Requested: objects.Temperature(*setpoint),
+			Adjusted:  adjusted,
+			Reason:    reason,
+		})
+
+		return Int(adjusted.Tenths())
+	}
+
+	clamp := func(field string, setpoint *int, min objects.Temperature, max objects.Temperature) *int {
+		if setpoint == nil {
+			return nil
+		}
+
+		switch t := objects.Temperature(*setpoint); {
+		case t < min:
+			return adjust(field, setpoint, min, fmt.Sprintf("%s is below the minimum %s", t, min))
+		case t > max:
+			return adjust(field, setpoint, max, fmt.Sprintf("%s is above the maximum %s", t, max))
+		}
+
+		return setpoint
+	}
+
+	heatField, coolField := "heatHoldTemp", "coolHoldTemp"
+
+	heat = clamp(heatField, heat, l.HeatMin, l.HeatMax)
+	cool = clamp(coolField, cool, l.CoolMin, l.CoolMax)
+
+	if heat != nil && cool != nil {
+		heatTemperature, coolTemperature := objects.Temperature(*heat), objects.Temperature(*cool)
+
+		if delta := coolTemperature.Sub(heatTemperature); delta < l.HeatCoolMinDelta {
+			reason := fmt.Sprintf("%s is less than %s above %s", coolTemperature, l.HeatCoolMinDelta, heatTemperature)
+
+			// Raise the cool setpoint if possible, otherwise lower the heat
+			// setpoint.
+			if raised := heatTemperature.Add(l.HeatCoolMinDelta); raised <= l.CoolMax {
+				cool = adjust(coolField, cool, raised, reason)
+			} else if lowered := l.CoolMax.Add(-l.HeatCoolMinDelta); lowered >= l.HeatMin {
+				if coolTemperature != l.CoolMax {
+					cool = adjust(coolField, cool, l.CoolMax, reason)
+				}
+
+				heat = adjust(heatField, heat, lowered, reason)
+			} else {
+				v.check(false, coolField, "no setpoints within the limits are %s apart", l.HeatCoolMinDelta)
+			}
+		}
+	}
+
+	if err := v.err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return heat, cool, adjustments, nil
+}
+
+// setpointLimits returns options.Limits, or the most restrictive limits of the
+// thermostats matching selection if it is nil.
+func (c *Client) setpointLimits(ctx context.Context, selection *objects.Selection, options *SafeHoldOptions) (*SetpointLimits, error) {
+	if options != nil && options.Limits != nil {
+		if err := options.Limits.check(); err != nil {
+			return nil, err
+		}
+
+		return options.Limits, nil
+	}
+
+	if selection == nil {
+		return nil, errors.New("setpoint limits: missing selection")
+	}
+
+	thermostatSelection := objects.Selection{
+		SelectionType:   selection.SelectionType,
+		SelectionMatch:  selection.SelectionMatch,
+		IncludeRuntime:  Bool(true),
+		IncludeSettings: Bool(true),
+	}
+
+	thermostats, err := c.ThermostatsAll(ctx, &thermostatSelection)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(thermostats) == 0 {
+		return nil, errors.New("setpoint limits: selection matched no thermostats")
+	}
+
+	var limits *SetpointLimits
+
+	for _, thermostat := range thermostats {
+		thermostatLimits, err := NewSetpointLimits(thermostat.Runtime, thermostat.Settings)
+		if err != nil {
+			return nil, err
+		}
+
+		if limits == nil {
+			limits = thermostatLimits
+		} else if err := limits.restrict(thermostatLimits); err != nil {
+			return nil, err
+		}
+	}
+
+	return limits, nil
+}
+
+// SafeSetHold is like SetHold but first guards the heat and cool hold
+// setpoints against the thermostat's limits, so the server does not silently
+// adjust them. Relative holds and holds without setpoints are sent as is. It
+// returns the adjustments made; parameters is not modified.
+func (c *Client) SafeSetHold(ctx context.Context, selection *objects.Selection, parameters *SetHoldParameters, options *SafeHoldOptions) (*APIStatusResponse, []SetpointAdjustment, error) {
+	if err := parameters.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	guardedParameters := *parameters
+	adjustments := []SetpointAdjustment{}
+
+	if (parameters.IsTemperatureRelative == nil || !*parameters.IsTemperatureRelative) && (parameters.HeatHoldTemp != nil || parameters.CoolHoldTemp != nil) {
+		limits, err := c.setpointLimits(ctx, selection, options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("setHold: %w", err)
+		}
+
+		guardedParameters.HeatHoldTemp, guardedParameters.CoolHoldTemp, adjustments, err = limits.guard("setHold", safeHoldMode(options), parameters.HeatHoldTemp, parameters.CoolHoldTemp)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	setHoldResponse, err := c.SetHold(ctx, selection, &guardedParameters)
+
+	return setHoldResponse, adjustments, err
+}
+
+// SafeCreateVacation is like CreateVacation but first guards the heat and cool
+// hold setpoints against the thermostat's limits, so the server does not
+// silently adjust them. It returns the adjustments made; parameters is not
+// modified.
+func (c *Client) SafeCreateVacation(ctx context.Context, selection *objects.Selection, parameters *CreateVacationParameters, options *SafeHoldOptions) (*APIStatusResponse, []SetpointAdjustment, error) {
+	if err := parameters.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	limits, err := c.setpointLimits(ctx, selection, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("createVacation: %w", err)
+	}
+
+	heat, cool, adjustments, err := limits.guard("createVacation", safeHoldMode(options), parameters.HeatHoldTemp, parameters.CoolHoldTemp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	guardedParameters := *parameters
+	guardedParameters.HeatHoldTemp, guardedParameters.CoolHoldTemp = heat, cool
+
+	createVacationResponse, err := c.CreateVacation(ctx, selection, &guardedParameters)
+
+	return createVacationResponse, adjustments, err
+}
+
+func safeHoldMode(options *SafeHoldOptions) SafeHoldMode {
+	if options == nil {
+		return SafeHoldModeClamp
+	}
+
+	return options.Mode
+}
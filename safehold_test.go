@@ -0,0 +1,295 @@
+package ecobee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+func TestSetpointLimitsGuard(t *testing.T) {
+	limits := SetpointLimits{HeatMin: 450, HeatMax: 790, CoolMin: 650, CoolMax: 920, HeatCoolMinDelta: 50}
+
+	// Limits whose cool maximum is too close to the heat maximum to always
+	// raise the cool setpoint.
+	narrowCool := SetpointLimits{HeatMin: 450, HeatMax: 790, CoolMin: 650, CoolMax: 800, HeatCoolMinDelta: 50}
+
+	// Limits allowing no heat and cool setpoints far enough apart.
+	noPair := SetpointLimits{HeatMin: 780, HeatMax: 790, CoolMin: 650, CoolMax: 800, HeatCoolMinDelta: 50}
+
+	tests := []struct {
+		name            string
+		limits          SetpointLimits
+		mode            SafeHoldMode
+		heat            *int
+		cool            *int
+		wantHeat        *int
+		wantCool        *int
+		wantAdjustments []SetpointAdjustment
+		wantFields      []string
+	}{
+		{
+			name:     "within limits",
+			limits:   limits,
+			heat:     Int(680),
+			cool:     Int(780),
+			wantHeat: Int(680),
+			wantCool: Int(780),
+		},
+		{
+			name:     "nil setpoints",
+			limits:   limits,
+			cool:     Int(950),
+			wantCool: Int(920),
+			wantAdjustments: []SetpointAdjustment{
+				{Field: "coolHoldTemp", Requested: 950, Adjusted: 920},
+			},
+		},
+		{
+			name:     "clamp below the minimum",
+			limits:   limits,
+			heat:     Int(400),
+			cool:     Int(780),
+			wantHeat: Int(450),
+			wantCool: Int(780),
+			wantAdjustments: []SetpointAdjustment{
+				{Field: "heatHoldTemp", Requested: 400, Adjusted: 450},
+			},
+		},
+		{
+			name:       "reject below the minimum",
+			limits:     limits,
+			mode:       SafeHoldModeReject,
+			heat:       Int(400),
+			cool:       Int(780),
+			wantFields: []string{"heatHoldTemp"},
+		},
+		{
+			name:       "reject above the maximum",
+			limits:     limits,
+			mode:       SafeHoldModeReject,
+			heat:       Int(680),
+			cool:       Int(950),
+			wantFields: []string{"coolHoldTemp"},
+		},
+		{
+			name:     "raise the cool setpoint",
+			limits:   limits,
+			heat:     Int(700),
+			cool:     Int(720),
+			wantHeat: Int(700),
+			wantCool: Int(750),
+			wantAdjustments: []SetpointAdjustment{
+				{Field: "coolHoldTemp", Requested: 720, Adjusted: 750},
+			},
+		},
+		{
+			name:       "reject setpoints too close",
+			limits:     limits,
+			mode:       SafeHoldModeReject,
+			heat:       Int(700),
+			cool:       Int(720),
+			wantFields: []string{"coolHoldTemp"},
+		},
+		{
+			name:     "lower the heat setpoint",
+			limits:   narrowCool,
+			heat:     Int(790),
+			cool:     Int(780),
+			wantHeat: Int(750),
+			wantCool: Int(800),
+			wantAdjustments: []SetpointAdjustment{
+				{Field: "coolHoldTemp", Requested: 780, Adjusted: 800},
+				{Field: "heatHoldTemp", Requested: 790, Adjusted: 750},
+			},
+		},
+		{
+			name:     "lower the heat setpoint with the cool setpoint at the maximum",
+			limits:   narrowCool,
+			heat:     Int(790),
+			cool:     Int(800),
+			wantHeat: Int(750),
+			wantCool: Int(800),
+			wantAdjustments: []SetpointAdjustment{
+				{Field: "heatHoldTemp", Requested: 790, Adjusted: 750},
+			},
+		},
+		{
+			name:     "field adjusted twice is reported once",
+			limits:   narrowCool,
+			heat:     Int(850),
+			cool:     Int(800),
+			wantHeat: Int(750),
+			wantCool: Int(800),
+			wantAdjustments: []SetpointAdjustment{
+				{Field: "heatHoldTemp", Requested: 850, Adjusted: 750},
+			},
+		},
+		{
+			name:       "no valid pair",
+			limits:     noPair,
+			heat:       Int(790),
+			cool:       Int(790),
+			wantFields: []string{"coolHoldTemp"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			heat, cool, adjustments, err := test.limits.guard("setHold", test.mode, test.heat, test.cool)
+
+			if len(test.wantFields) > 0 {
+				validationError := &ValidationError{}
+				if !errors.As(err, &validationError) {
+					t.Fatalf("guard() error = %v, want a *ValidationError", err)
+				}
+
+				fields := []string{}
+
+				for _, fieldError := range validationError.FieldErrors() {
+					fields = append(fields, fieldError.Field())
+				}
+
+				if !reflect.DeepEqual(fields, test.wantFields) {
+					t.Errorf("FieldErrors() fields = %q, want %q", fields, test.wantFields)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("guard() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(heat, test.wantHeat) || !reflect.DeepEqual(cool, test.wantCool) {
+				t.Errorf("guard() = %v, %v, want %v, %v", heat, cool, test.wantHeat, test.wantCool)
+			}
+
+			if len(adjustments) != len(test.wantAdjustments) {
+				t.Fatalf("guard() adjustments = %+v, want %+v", adjustments, test.wantAdjustments)
+			}
+
+			for i, adjustment := range adjustments {
+				want := test.wantAdjustments[i]
+
+				if adjustment.Field != want.Field || adjustment.Requested != want.Requested || adjustment.Adjusted != want.Adjusted || adjustment.Reason == "" {
+					t.Errorf("guard() adjustments[%d] = %+v, want %+v with a reason", i, adjustment, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetpointLimitsRestrict(t *testing.T) {
+	limits := SetpointLimits{HeatMin: 450, HeatMax: 790, CoolMin: 650, CoolMax: 920, HeatCoolMinDelta: 50}
+
+	if err := limits.restrict(&SetpointLimits{HeatMin: 500, HeatMax: 800, CoolMin: 600, CoolMax: 900, HeatCoolMinDelta: 40}); err != nil {
+		t.Fatalf("restrict() error = %v", err)
+	}
+
+	want := SetpointLimits{HeatMin: 500, HeatMax: 790, CoolMin: 650, CoolMax: 900, HeatCoolMinDelta: 50}
+	if limits != want {
+		t.Errorf("restrict() = %+v, want %+v", limits, want)
+	}
+
+	if err := limits.restrict(&SetpointLimits{HeatMin: 800, HeatMax: 850, CoolMin: 650, CoolMax: 900}); err == nil {
+		t.Error("restrict() error = nil, want an error for disjoint heat ranges")
+	}
+}
+
+func TestSafeSetHoldFetchesLimits(t *testing.T) {
+	functions := []objects.Function{}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			request := struct {
+				Functions []objects.Function `json:"functions"`
+			}{}
+
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("json.Decode() error = %v", err)
+			}
+
+			functions = append(functions, request.Functions...)
+
+			_, _ = w.Write([]byte(`{"status":{"code":0,"message":""}}`))
+
+			return
+		}
+
+		request := struct {
+			Selection objects.Selection `json:"selection"`
+		}{}
+
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("json")), &request); err != nil {
+			t.Errorf("json.Unmarshal() error = %v", err)
+		}
+
+		if request.Selection.IncludeRuntime == nil || !*request.Selection.IncludeRuntime || request.Selection.IncludeSettings == nil || !*request.Selection.IncludeSettings {
+			t.Errorf("selection = %+v, want the runtime and settings included", request.Selection)
+		}
+
+		// The most restrictive limits are heat [500, 790], cool [650, 800]
+		// and a minimum difference of 50.
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"page": objects.Page{Page: Int(1), TotalPages: Int(1), PageSize: Int(2), Total: Int(2)},
+			"thermostatList": []objects.Thermostat{
+				{
+					Identifier: String("1"),
+					Runtime:    &objects.Runtime{DesiredHeatRange: []int{450, 790}, DesiredCoolRange: []int{650, 920}},
+					Settings:   &objects.Settings{HeatCoolMinDelta: Int(40)},
+				},
+				{
+					Identifier: String("2"),
+					Runtime:    &objects.Runtime{DesiredHeatRange: []int{500, 850}, DesiredCoolRange: []int{600, 800}},
+					Settings:   &objects.Settings{HeatCoolMinDelta: Int(50)},
+				},
+			},
+			"status": objects.Status{Code: Int(0), Message: String("")},
+		})
+	})
+
+	parameters := &SetHoldParameters{HeatHoldTemp: Int(400), CoolHoldTemp: Int(900)}
+
+	_, adjustments, err := client.SafeSetHold(context.Background(), &objects.Selection{SelectionType: String("registered")}, parameters, nil)
+	if err != nil {
+		t.Fatalf("SafeSetHold() error = %v", err)
+	}
+
+	fields := []string{}
+
+	for _, adjustment := range adjustments {
+		fields = append(fields, adjustment.Field)
+	}
+
+	if strings.Join(fields, ",") != "heatHoldTemp,coolHoldTemp" {
+		t.Errorf("SafeSetHold() adjustments = %+v, want heatHoldTemp and coolHoldTemp", adjustments)
+	}
+
+	if *parameters.HeatHoldTemp != 400 || *parameters.CoolHoldTemp != 900 {
+		t.Errorf("SafeSetHold() modified parameters to %d, %d", *parameters.HeatHoldTemp, *parameters.CoolHoldTemp)
+	}
+
+	if len(functions) != 1 {
+		t.Fatalf("functions = %+v, want 1 setHold", functions)
+	}
+
+	// JSON numbers decode as float64.
+	if heat, cool := functions[0].Params["heatHoldTemp"], functions[0].Params["coolHoldTemp"]; heat != 500.0 || cool != 800.0 {
+		t.Errorf("setHold heatHoldTemp, coolHoldTemp = %v, %v, want 500, 800", heat, cool)
+	}
+
+	_, _, err = client.SafeSetHold(context.Background(), &objects.Selection{SelectionType: String("registered")}, parameters, &SafeHoldOptions{Mode: SafeHoldModeReject})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("SafeSetHold() error = %v, want a *ValidationError", err)
+	}
+
+	if len(functions) != 1 {
+		t.Errorf("functions = %+v, want no request after a rejected hold", functions)
+	}
+}
@@ -0,0 +1,207 @@
+package ecobee
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+// A ThermostatRevision describes an entry of the revision list returned by
+// ThermostatSummary.
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/operations/get-thermostat-summary.shtml
+type ThermostatRevision struct {
+	// The thermostat identifier.
+	Identifier string
+	// The thermostat name, otherwise an empty field if one is not set.
+	Name string
+	// Whether the thermostat is currently connected to the ecobee servers.
+	Connected bool
+	// Changes when the thermostat program, hvac mode, settings or
+	// configuration change. Changes to the following objects will update the
+	// thermostat revision: Settings, Program, Event, Device.
+	ThermostatRevision string
+	// Changes whenever an alert is issued or acknowledged.
+	AlertsRevision string
+	// Changes when the thermostat's connection state changes, or when its
+	// equipment starts or stops running.
+	RuntimeRevision string
+	// Changes whenever the thermostat reports new interval data, roughly every
+	// 15 minutes.
+	IntervalRevision string
+}
+
+// ParseThermostatRevision parses an entry of the revision list returned by
+// ThermostatSummary. The entry is a colon separated list of the identifier,
+// name, connected, thermostat revision, alerts revision, runtime revision and
+// interval revision.
+func ParseThermostatRevision(revision string) (ThermostatRevision, error) {
+	fields := strings.Split(revision, ":")
+	if len(fields) < 7 {
+		return ThermostatRevision{}, fmt.Errorf("%s: revision %q: expected 7 fields, got %d", thermostatSummaryEndpoint, revision, len(fields))
+	}
+
+	// The name is the only field that may contain a colon.
+	n := len(fields)
+
+	connected, err := strconv.ParseBool(fields[n-5])
+	if err != nil {
+		return ThermostatRevision{}, fmt.Errorf("%s: revision %q: connected: %w", thermostatSummaryEndpoint, revision, err)
+	}
+
+	return ThermostatRevision{
+		Identifier:         fields[0],
+		Name:               strings.Join(fields[1:n-5], ":"),
+		Connected:          connected,
+		ThermostatRevision: fields[n-4],
+		AlertsRevision:     fields[n-3],
+		RuntimeRevision:    fields[n-2],
+		IntervalRevision:   fields[n-1],
+	}, nil
+}
+
+// A RevisionChanges describes which revisions of a thermostat changed
+// between two ThermostatSummary responses.
+type RevisionChanges struct {
+	// Whether the thermostat was not present in the previous response.
+	Added bool
+	// Whether the thermostat revision changed.
+	Thermostat bool
+	// Whether the alerts revision changed.
+	Alerts bool
+	// Whether the runtime revision changed.
+	Runtime bool
+	// Whether the interval revision changed.
+	Interval bool
+	// Whether the connected state changed.
+	Connected bool
+}
+
+// Any reports whether any revision or the connected state changed.
+func (r RevisionChanges) Any() bool {
+	return r.Added || r.Thermostat || r.Alerts || r.Runtime || r.Interval || r.Connected
+}
+
+// Changes returns which revisions of r changed since previous.
+func (r ThermostatRevision) Changes(previous ThermostatRevision) RevisionChanges {
+	return RevisionChanges{
+		Thermostat: r.ThermostatRevision != previous.ThermostatRevision,
+		Alerts:     r.AlertsRevision != previous.AlertsRevision,
+		Runtime:    r.RuntimeRevision != previous.RuntimeRevision,
+		Interval:   r.IntervalRevision != previous.IntervalRevision,
+		Connected:  r.Connected != previous.Connected,
+	}
+}
+
+// CompareRevisions returns the changes of every thermostat in current whose
+// revisions differ from previous, keyed by thermostat identifier. Thermostats
+// missing from previous are reported as added with every revision changed.
+// Thermostats missing from current are not reported.
+func CompareRevisions(previous map[string]ThermostatRevision, current map[string]ThermostatRevision) map[string]RevisionChanges {
+	changes := make(map[string]RevisionChanges)
+
+	for identifier, revision := range current {
+		previousRevision, ok := previous[identifier]
+		if !ok {
+			changes[identifier] = RevisionChanges{
+				Added:      true,
+				Thermostat: true,
+				Alerts:     true,
+				Runtime:    true,
+				Interval:   true,
+			}
+
+			continue
+		}
+
+		if revisionChanges := revision.Changes(previousRevision); revisionChanges.Any() {
+			changes[identifier] = revisionChanges
+		}
+	}
+
+	return changes
+}
+
+// An EquipmentStatusEntry describes an entry of the status list returned by
+// ThermostatSummary.
+type EquipmentStatusEntry struct {
+	// The thermostat identifier.
+	Identifier string
+	// The equipment currently running. Empty if no equipment is running.
+	EquipmentStatus objects.EquipmentStatus
+}
+
+// ParseEquipmentStatusEntry parses an entry of the status list returned by
+// ThermostatSummary. The entry is the thermostat identifier followed by a
+// colon and a comma separated list of the equipment currently running.
+func ParseEquipmentStatusEntry(status string) (EquipmentStatusEntry, error) {
+	identifier, equipmentStatus, ok := strings.Cut(status, ":")
+	if !ok || identifier == "" {
+		return EquipmentStatusEntry{}, fmt.Errorf("%s: status %q: expected identifier:equipmentStatus", thermostatSummaryEndpoint, status)
+	}
+
+	return EquipmentStatusEntry{
+		Identifier:      identifier,
+		EquipmentStatus: objects.ParseEquipmentStatus(equipmentStatus),
+	}, nil
+}
+
+// Revisions returns the response's parsed revision list keyed by thermostat
+// identifier.
+func (t *ThermostatSummarySuccessResponse) Revisions() (map[string]ThermostatRevision, error) {
+	revisions := make(map[string]ThermostatRevision, len(t.revisionList))
+
+	for _, revision := range t.revisionList {
+		thermostatRevision, err := ParseThermostatRevision(revision)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions[thermostatRevision.Identifier] = thermostatRevision
+	}
+
+	return revisions, nil
+}
+
+// EquipmentStatuses returns the response's parsed status list keyed by
+// thermostat identifier. The status list is only returned if the selection
+// includes the equipment status.
+func (t *ThermostatSummarySuccessResponse) EquipmentStatuses() (map[string]EquipmentStatusEntry, error) {
+	equipmentStatuses := make(map[string]EquipmentStatusEntry, len(t.statusList))
+
+	for _, status := range t.statusList {
+		entry, err := ParseEquipmentStatusEntry(status)
+		if err != nil {
+			return nil, err
+		}
+
+		equipmentStatuses[entry.Identifier] = entry
+	}
+
+	return equipmentStatuses, nil
+}
+
+// ChangesSince returns the changes of every thermostat whose revisions differ
+// from the previous response, keyed by thermostat identifier. A nil previous
+// response reports every thermostat as added. See CompareRevisions.
+func (t *ThermostatSummarySuccessResponse) ChangesSince(previous *ThermostatSummarySuccessResponse) (map[string]RevisionChanges, error) {
+	previousRevisions := map[string]ThermostatRevision{}
+
+	if previous != nil {
+		revisions, err := previous.Revisions()
+		if err != nil {
+			return nil, err
+		}
+
+		previousRevisions = revisions
+	}
+
+	revisions, err := t.Revisions()
+	if err != nil {
+		return nil, err
+	}
+
+	return CompareRevisions(previousRevisions, revisions), nil
+}
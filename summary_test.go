@@ -0,0 +1,262 @@
+package ecobee
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+func TestParseThermostatRevision(t *testing.T) {
+	tests := []struct {
+		name     string
+		revision string
+		want     ThermostatRevision
+		wantErr  bool
+	}{
+		{
+			name:     "revision",
+			revision: "123456789012:Living Room:true:170101000000:170102000000:170103000000:170104000000",
+			want: ThermostatRevision{
+				Identifier:         "123456789012",
+				Name:               "Living Room",
+				Connected:          true,
+				ThermostatRevision: "170101000000",
+				AlertsRevision:     "170102000000",
+				RuntimeRevision:    "170103000000",
+				IntervalRevision:   "170104000000",
+			},
+		},
+		{
+			name:     "name with colons",
+			revision: "123456789012:Den: Upstairs:false:1:2:3:4",
+			want: ThermostatRevision{
+				Identifier:         "123456789012",
+				Name:               "Den: Upstairs",
+				ThermostatRevision: "1",
+				AlertsRevision:     "2",
+				RuntimeRevision:    "3",
+				IntervalRevision:   "4",
+			},
+		},
+		{
+			name:     "empty name",
+			revision: "123456789012::true:1:2:3:4",
+			want: ThermostatRevision{
+				Identifier:         "123456789012",
+				Connected:          true,
+				ThermostatRevision: "1",
+				AlertsRevision:     "2",
+				RuntimeRevision:    "3",
+				IntervalRevision:   "4",
+			},
+		},
+		{
+			name:     "missing fields",
+			revision: "123456789012:Living Room:true:1:2:3",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid connected",
+			revision: "123456789012:Living Room:yes:1:2:3:4",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseThermostatRevision(test.revision)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseThermostatRevision() error = %v, want error %t", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("ParseThermostatRevision() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseEquipmentStatusEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		want    EquipmentStatusEntry
+		wantErr bool
+	}{
+		{
+			name:   "running equipment",
+			status: "123456789012:heatPump,fan",
+			want: EquipmentStatusEntry{
+				Identifier:      "123456789012",
+				EquipmentStatus: objects.EquipmentStatus{objects.EquipmentHeatPump: {}, objects.EquipmentFan: {}},
+			},
+		},
+		{
+			name:   "idle",
+			status: "123456789012:",
+			want: EquipmentStatusEntry{
+				Identifier:      "123456789012",
+				EquipmentStatus: objects.EquipmentStatus{},
+			},
+		},
+		{
+			name:    "missing colon",
+			status:  "123456789012",
+			wantErr: true,
+		},
+		{
+			name:    "missing identifier",
+			status:  ":fan",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseEquipmentStatusEntry(test.status)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseEquipmentStatusEntry() error = %v, want error %t", err, test.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ParseEquipmentStatusEntry() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestThermostatSummaryParsers(t *testing.T) {
+	response := ThermostatSummarySuccessResponse{}
+
+	if err := json.Unmarshal([]byte(`{
+		"revisionList": ["1:One:true:a:b:c:d", "2:Two:false:a:b:c:d"],
+		"thermostatCount": 2,
+		"statusList": ["1:compCool1,fan", "2:"],
+		"status": {"code": 0, "message": ""}
+	}`), &response); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	revisions, err := response.Revisions()
+	if err != nil {
+		t.Fatalf("Revisions() error = %v", err)
+	}
+
+	if len(revisions) != 2 || revisions["1"].Name != "One" || !revisions["1"].Connected || revisions["2"].Connected {
+		t.Errorf("Revisions() = %+v, want thermostats 1 and 2", revisions)
+	}
+
+	equipmentStatuses, err := response.EquipmentStatuses()
+	if err != nil {
+		t.Fatalf("EquipmentStatuses() error = %v", err)
+	}
+
+	if got := equipmentStatuses["1"].EquipmentStatus.String(); got != "compCool1,fan" {
+		t.Errorf("EquipmentStatuses()[1] = %q, want %q", got, "compCool1,fan")
+	}
+
+	if got := len(equipmentStatuses["2"].EquipmentStatus); got != 0 {
+		t.Errorf("len(EquipmentStatuses()[2]) = %d, want 0", got)
+	}
+
+	invalid := ThermostatSummarySuccessResponse{}
+
+	if err := json.Unmarshal([]byte(`{"revisionList": ["1:One"], "statusList": ["1"]}`), &invalid); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if _, err := invalid.Revisions(); err == nil {
+		t.Error("Revisions() error = nil, want an error")
+	}
+
+	if _, err := invalid.EquipmentStatuses(); err == nil {
+		t.Error("EquipmentStatuses() error = nil, want an error")
+	}
+
+	changes, err := response.ChangesSince(nil)
+	if err != nil {
+		t.Fatalf("ChangesSince(nil) error = %v", err)
+	}
+
+	if len(changes) != 2 || !changes["1"].Added || !changes["2"].Added {
+		t.Errorf("ChangesSince(nil) = %+v, want both thermostats added", changes)
+	}
+}
+
+func TestCompareRevisions(t *testing.T) {
+	revision := ThermostatRevision{
+		Identifier:         "1",
+		Connected:          true,
+		ThermostatRevision: "t1",
+		AlertsRevision:     "a1",
+		RuntimeRevision:    "r1",
+		IntervalRevision:   "i1",
+	}
+
+	with := func(modify func(r *ThermostatRevision)) ThermostatRevision {
+		r := revision
+		modify(&r)
+
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		previous map[string]ThermostatRevision
+		current  map[string]ThermostatRevision
+		want     map[string]RevisionChanges
+	}{
+		{
+			name:     "unchanged",
+			previous: map[string]ThermostatRevision{"1": revision},
+			current:  map[string]ThermostatRevision{"1": revision},
+			want:     map[string]RevisionChanges{},
+		},
+		{
+			name:     "added",
+			previous: map[string]ThermostatRevision{},
+			current:  map[string]ThermostatRevision{"1": revision},
+			want:     map[string]RevisionChanges{"1": {Added: true, Thermostat: true, Alerts: true, Runtime: true, Interval: true}},
+		},
+		{
+			name:     "removed",
+			previous: map[string]ThermostatRevision{"1": revision},
+			current:  map[string]ThermostatRevision{},
+			want:     map[string]RevisionChanges{},
+		},
+		{
+			name:     "thermostat and interval",
+			previous: map[string]ThermostatRevision{"1": revision},
+			current: map[string]ThermostatRevision{"1": with(func(r *ThermostatRevision) {
+				r.ThermostatRevision, r.IntervalRevision = "t2", "i2"
+			})},
+			want: map[string]RevisionChanges{"1": {Thermostat: true, Interval: true}},
+		},
+		{
+			name:     "alerts and runtime",
+			previous: map[string]ThermostatRevision{"1": revision},
+			current: map[string]ThermostatRevision{"1": with(func(r *ThermostatRevision) {
+				r.AlertsRevision, r.RuntimeRevision = "a2", "r2"
+			})},
+			want: map[string]RevisionChanges{"1": {Alerts: true, Runtime: true}},
+		},
+		{
+			name:     "disconnected",
+			previous: map[string]ThermostatRevision{"1": revision},
+			current: map[string]ThermostatRevision{"1": with(func(r *ThermostatRevision) {
+				r.Connected = false
+			})},
+			want: map[string]RevisionChanges{"1": {Connected: true}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := CompareRevisions(test.previous, test.current); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("CompareRevisions() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sherif-fanous/go-ecobee/objects"
 	"io"
 	"io/ioutil"
+	"iter"
 	"net/url"
 )
 
@@ -19,8 +20,90 @@ const (
 // Thermostat retrieves a selection of thermostat data for one or more
 // thermostats.
 //
+// A selection of type thermostats matching more than 25 thermostats is split
+// into batches of 25 thermostats whose thermostat lists are merged. page is
+// requested from every batch, and the merged page's TotalPages is the largest
+// number of pages of any batch; use Thermostats to iterate over the pages of
+// every batch. If any batch fails the merged response of the batches that
+// succeeded is returned along with a *BatchError.
+//
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/operations/get-thermostats.shtml
 func (c *Client) Thermostat(ctx context.Context, selection *objects.Selection, page *objects.Page) (*ThermostatSuccessResponse, error) {
+	selections := splitSelection(selection)
+	if selections == nil {
+		return c.thermostat(ctx, selection, page)
+	}
+
+	return c.thermostatBatches(ctx, selections, page)
+}
+
+// thermostatBatches retrieves page of every batch of a split selection and
+// merges the responses. If some batches fail the merged response of the
+// batches that succeeded is returned along with a *BatchError.
+func (c *Client) thermostatBatches(ctx context.Context, selections []*objects.Selection, page *objects.Page) (*ThermostatSuccessResponse, error) {
+	responses := make([]*ThermostatSuccessResponse, len(selections))
+
+	err := c.runBatches(ctx, thermostatEndpoint, selections, func(ctx context.Context, i int, selection *objects.Selection) error {
+		thermostatResponse, err := c.thermostat(ctx, selection, page)
+		responses[i] = thermostatResponse
+
+		return err
+	})
+
+	thermostatSuccessResponse := ThermostatSuccessResponse{
+		batchPages: make([]*objects.Page, len(selections)),
+	}
+
+	merged, paged, totalPages, total := false, false, 0, 0
+
+	for i, thermostatResponse := range responses {
+		if thermostatResponse == nil {
+			continue
+		}
+
+		if !merged {
+			thermostatSuccessResponse.status = thermostatResponse.status
+			merged = true
+		}
+
+		thermostatSuccessResponse.thermostatList = append(thermostatSuccessResponse.thermostatList, thermostatResponse.thermostatList...)
+		thermostatSuccessResponse.batchPages[i] = thermostatResponse.page
+
+		if thermostatResponse.page != nil {
+			paged = true
+
+			if thermostatResponse.page.TotalPages != nil && *thermostatResponse.page.TotalPages > totalPages {
+				totalPages = *thermostatResponse.page.TotalPages
+			}
+
+			if thermostatResponse.page.Total != nil {
+				total += *thermostatResponse.page.Total
+			}
+		}
+	}
+
+	if !merged {
+		return nil, err
+	}
+
+	if paged {
+		pageNumber := 1
+		if page != nil && page.Page != nil {
+			pageNumber = *page.Page
+		}
+
+		thermostatSuccessResponse.page = &objects.Page{
+			Page:       Int(pageNumber),
+			TotalPages: Int(totalPages),
+			PageSize:   Int(len(thermostatSuccessResponse.thermostatList)),
+			Total:      Int(total),
+		}
+	}
+
+	return &thermostatSuccessResponse, err
+}
+
+func (c *Client) thermostat(ctx context.Context, selection *objects.Selection, page *objects.Page) (*ThermostatSuccessResponse, error) {
 	data, err := json.Marshal(struct {
 		Selection *objects.Selection `json:"selection,omitempty"`
 		Page      *objects.Page      `json:"page,omitempty"`
@@ -35,7 +118,7 @@ func (c *Client) Thermostat(ctx context.Context, selection *objects.Selection, p
 	queryParameters := url.Values{}
 	queryParameters.Set("json", string(data))
 
-	resp, err := c.get(ctx, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, thermostatEndpoint), queryParameters, nil)
+	resp, err := c.get(ctx, thermostatEndpoint, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, thermostatEndpoint), selection, queryParameters, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", thermostatEndpoint, err)
 	}
@@ -56,6 +139,101 @@ func (c *Client) Thermostat(ctx context.Context, selection *objects.Selection, p
 	return &thermostatSuccessResponse, nil
 }
 
+// Thermostats returns an iterator over the thermostats matching selection.
+// Pages are retrieved lazily as the iteration progresses, so stopping the
+// iteration early avoids requesting the remaining pages. A selection of type
+// thermostats matching more than 25 thermostats is split into batches as by
+// Thermostat, and every batch is paged through on its own: once a batch has
+// returned its last page it is no longer requested.
+//
+// If a page cannot be retrieved, or ctx is done before the next page is
+// requested, the error is yielded with a zero Thermostat and the iteration
+// stops. The thermostats of the batches that succeeded are yielded before the
+// *BatchError of the batches that failed.
+func (c *Client) Thermostats(ctx context.Context, selection *objects.Selection) iter.Seq2[objects.Thermostat, error] {
+	return func(yield func(objects.Thermostat, error) bool) {
+		selections := splitSelection(selection)
+
+		for page := 1; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(objects.Thermostat{}, fmt.Errorf("%s: %w", thermostatEndpoint, err))
+
+				return
+			}
+
+			var thermostatResponse *ThermostatSuccessResponse
+			var err error
+
+			if selections == nil {
+				thermostatResponse, err = c.thermostat(ctx, selection, &objects.Page{Page: Int(page)})
+			} else {
+				thermostatResponse, err = c.thermostatBatches(ctx, selections, &objects.Page{Page: Int(page)})
+				selections = remainingBatches(selections, thermostatResponse, page)
+			}
+
+			if thermostatResponse != nil {
+				for _, thermostat := range thermostatResponse.thermostatList {
+					if !yield(thermostat, nil) {
+						return
+					}
+				}
+			}
+
+			if err != nil {
+				yield(objects.Thermostat{}, err)
+
+				return
+			}
+
+			if selections != nil {
+				if len(selections) == 0 {
+					return
+				}
+
+				continue
+			}
+
+			if responsePage := thermostatResponse.page; responsePage == nil || responsePage.TotalPages == nil || page >= *responsePage.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// remainingBatches returns the batches of selections that have pages after
+// page, according to the batch pages of thermostatResponse.
+func remainingBatches(selections []*objects.Selection, thermostatResponse *ThermostatSuccessResponse, page int) []*objects.Selection {
+	remaining := []*objects.Selection{}
+
+	if thermostatResponse == nil {
+		return remaining
+	}
+
+	for i, batchPage := range thermostatResponse.batchPages {
+		if batchPage != nil && batchPage.TotalPages != nil && page < *batchPage.TotalPages {
+			remaining = append(remaining, selections[i])
+		}
+	}
+
+	return remaining
+}
+
+// ThermostatsAll retrieves the thermostats matching selection across all
+// pages.
+func (c *Client) ThermostatsAll(ctx context.Context, selection *objects.Selection) ([]objects.Thermostat, error) {
+	thermostats := []objects.Thermostat{}
+
+	for thermostat, err := range c.Thermostats(ctx, selection) {
+		if err != nil {
+			return nil, err
+		}
+
+		thermostats = append(thermostats, thermostat)
+	}
+
+	return thermostats, nil
+}
+
 // ThermostatSummary retrieves a list of thermostat configuration and state
 // revisions.
 //
@@ -73,7 +251,7 @@ func (c *Client) ThermostatSummary(ctx context.Context, selection *objects.Selec
 	queryParameters := url.Values{}
 	queryParameters.Set("json", string(data))
 
-	resp, err := c.get(ctx, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, thermostatSummaryEndpoint), queryParameters, nil)
+	resp, err := c.get(ctx, thermostatSummaryEndpoint, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, thermostatSummaryEndpoint), selection, queryParameters, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", thermostatSummaryEndpoint, err)
 	}
@@ -97,8 +275,33 @@ func (c *Client) ThermostatSummary(ctx context.Context, selection *objects.Selec
 // UpdateThermostat permits the modification of any writable thermostat or
 // sub-object property.
 //
+// A selection of type thermostats matching more than 25 thermostats is split
+// into batches of 25 thermostats. Batches are not applied atomically: if any
+// batch fails a *BatchError listing the failed batches is returned and the
+// other batches have been applied.
+//
 // For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/operations/post-update-thermostats.shtml
 func (c *Client) UpdateThermostat(ctx context.Context, selection *objects.Selection, thermostat *objects.Thermostat, functions []objects.Function) (*APIStatusResponse, error) {
+	selections := splitSelection(selection)
+	if selections == nil {
+		return c.updateThermostat(ctx, selection, thermostat, functions)
+	}
+
+	responses := make([]*APIStatusResponse, len(selections))
+
+	if err := c.runBatches(ctx, thermostatEndpoint, selections, func(ctx context.Context, i int, selection *objects.Selection) error {
+		updateThermostatResponse, err := c.updateThermostat(ctx, selection, thermostat, functions)
+		responses[i] = updateThermostatResponse
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+func (c *Client) updateThermostat(ctx context.Context, selection *objects.Selection, thermostat *objects.Thermostat, functions []objects.Function) (*APIStatusResponse, error) {
 	data, err := json.Marshal(struct {
 		Selection  *objects.Selection  `json:"selection,omitempty"`
 		Thermostat *objects.Thermostat `json:"thermostat,omitempty"`
@@ -115,7 +318,7 @@ func (c *Client) UpdateThermostat(ctx context.Context, selection *objects.Select
 	queryParameters := url.Values{}
 	queryParameters.Set("format", "json")
 
-	resp, err := c.post(ctx, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, thermostatEndpoint), queryParameters, nil, bytes.NewBuffer(data))
+	resp, err := c.post(ctx, thermostatEndpoint, fmt.Sprintf("%s%d/%s", c.apiBaseURL, c.apiVersion, thermostatEndpoint), selection, queryParameters, nil, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", thermostatEndpoint, err)
 	}
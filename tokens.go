@@ -2,10 +2,16 @@ package ecobee
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
 )
 
 const (
@@ -13,6 +19,11 @@ const (
 	tokenEndpoint     = "token"
 )
 
+const (
+	defaultPINPollingInterval   = 30 * time.Second
+	slowDownPINPollingIncrement = 5 * time.Second
+)
+
 // Scope details the intent of the API application towards the user's account.
 type Scope string
 
@@ -33,7 +44,7 @@ func (c *Client) PINAuthorization(ctx context.Context, applicationKey string, sc
 	queryParameters.Set("client_id", applicationKey)
 	queryParameters.Set("scope", string(scope))
 
-	resp, err := c.get(ctx, fmt.Sprintf("%s%s", c.apiBaseURL, authorizeEndpoint), queryParameters, nil)
+	resp, err := c.get(ctx, authorizeEndpoint, fmt.Sprintf("%s%s", c.apiBaseURL, authorizeEndpoint), nil, queryParameters, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", authorizeEndpoint, err)
 	}
@@ -47,8 +58,8 @@ func (c *Client) PINAuthorization(ctx context.Context, applicationKey string, sc
 
 	authorizeResponse := PINAuthorizationSuccessResponse{}
 
-	if err := processAuthorizationResponse(tokenEndpoint, resp, &authorizeResponse); err != nil {
-		return &authorizeResponse, err
+	if err := processAuthorizationResponse(authorizeEndpoint, resp, &authorizeResponse); err != nil {
+		return nil, err
 	}
 
 	return &authorizeResponse, nil
@@ -64,7 +75,9 @@ func (c *Client) RequestTokens(ctx context.Context, applicationKey string, autho
 	queryParameters.Set("code", authorizationToken)
 	queryParameters.Set("client_id", applicationKey)
 
-	resp, err := c.post(ctx, fmt.Sprintf("%s%s", c.apiBaseURL, tokenEndpoint), queryParameters, nil, nil)
+	issuedAt := time.Now().UTC()
+
+	resp, err := c.post(ctx, tokenEndpoint, fmt.Sprintf("%s%s", c.apiBaseURL, tokenEndpoint), nil, queryParameters, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", tokenEndpoint, err)
 	}
@@ -77,10 +90,171 @@ func (c *Client) RequestTokens(ctx context.Context, applicationKey string, autho
 	}()
 
 	tokensResponse := TokensSuccessResponse{}
+	tokensResponse.issuedAt = issuedAt
 
 	if err := processAuthorizationResponse(tokenEndpoint, resp, &tokensResponse); err != nil {
-		return &tokensResponse, nil
+		return nil, err
+	}
+
+	return &tokensResponse, nil
+}
+
+// RefreshTokens requests a new pair of access and refresh tokens using a
+// previously issued refresh token. ecobee rotates the refresh token on every
+// call so the returned refresh token must replace the one passed in.
+//
+// If the refresh token has expired or has been revoked the returned error
+// satisfies errors.Is(err, ErrInvalidGrant).
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/auth/token-refresh.shtml
+func (c *Client) RefreshTokens(ctx context.Context, applicationKey string, refreshToken string) (*TokensSuccessResponse, error) {
+	queryParameters := url.Values{}
+	queryParameters.Set("grant_type", "refresh_token")
+	queryParameters.Set("code", refreshToken)
+	queryParameters.Set("client_id", applicationKey)
+
+	issuedAt := time.Now().UTC()
+
+	resp, err := c.post(ctx, tokenEndpoint, fmt.Sprintf("%s%s", c.apiBaseURL, tokenEndpoint), nil, queryParameters, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tokenEndpoint, err)
+	}
+
+	defer func() {
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}()
+
+	tokensResponse := TokensSuccessResponse{}
+	tokensResponse.issuedAt = issuedAt
+
+	if err := processAuthorizationResponse(tokenEndpoint, resp, &tokensResponse); err != nil {
+		return nil, err
+	}
+
+	return &tokensResponse, nil
+}
+
+// AuthorizeWithPIN runs the complete ecobee PIN authorization flow. It
+// requests a PIN, hands it to prompt so it can be shown to the user, then
+// polls for tokens at the interval specified by the server until the user
+// installs the application within the ecobee Web Portal.
+//
+// Polling stops when ctx is done, when prompt returns an error, or when the
+// PIN expires, in which case the returned error satisfies
+// errors.Is(err, ErrAuthorizationExpired).
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/auth/pin-api-authorization.shtml
+func (c *Client) AuthorizeWithPIN(ctx context.Context, applicationKey string, scope Scope, prompt func(*PINAuthorizationSuccessResponse) error) (*oauth2.Token, error) {
+	authorizeResponse, err := c.PINAuthorization(ctx, applicationKey, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// expires_in is expressed in minutes for the PIN authorization method.
+	deadline := time.Now().Add(time.Minute * time.Duration(authorizeResponse.ExpiresIn()))
+
+	pollingInterval := time.Second * time.Duration(authorizeResponse.PollingInterval())
+	if pollingInterval <= 0 {
+		pollingInterval = defaultPINPollingInterval
+	}
+
+	if err := prompt(authorizeResponse); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(pollingInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s: %w", tokenEndpoint, ErrAuthorizationExpired)
+		}
+
+		tokensResponse, err := c.RequestTokens(ctx, applicationKey, authorizeResponse.AuthorizationToken())
+		if err == nil {
+			return tokensResponse.OAuth2Token(), nil
+		}
+
+		var authorizationError *AuthorizationError
+
+		if !errors.As(err, &authorizationError) {
+			return nil, err
+		}
+
+		switch authorizationError.ErrorType() {
+		case AuthorizationErrorTypeAuthorizationPending:
+		case AuthorizationErrorTypeSlowDown:
+			pollingInterval += slowDownPINPollingIncrement
+		default:
+			return nil, err
+		}
+
+		timer.Reset(pollingInterval)
 	}
+}
+
+type tokenSource struct {
+	ctx            context.Context
+	client         *Client
+	applicationKey string
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// NewTokenSource returns an oauth2.TokenSource that returns token until it
+// expires, then refreshes it using the ecobee refresh_token grant. The
+// returned TokenSource is safe for concurrent use.
+//
+// ctx is used for every refresh request. If ctx carries an *http.Client under
+// the oauth2.HTTPClient key that client is used to perform the refresh.
+func NewTokenSource(ctx context.Context, applicationKey string, token *oauth2.Token) oauth2.TokenSource {
+	refreshClient := NewClient()
+
+	if httpClient, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		refreshClient.httpClient = httpClient
+	}
+
+	return newTokenSource(ctx, refreshClient, applicationKey, token)
+}
+
+func newTokenSource(ctx context.Context, refreshClient *Client, applicationKey string, token *oauth2.Token) oauth2.TokenSource {
+	return &tokenSource{
+		ctx:            ctx,
+		client:         refreshClient,
+		applicationKey: applicationKey,
+		token:          token,
+	}
+}
+
+// Token implements the oauth2.TokenSource interface.
+func (t *tokenSource) Token() (*oauth2.Token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token.Valid() {
+		return t.token, nil
+	}
+
+	if t.token == nil || t.token.RefreshToken == "" {
+		return nil, fmt.Errorf("%s: %w", tokenEndpoint, ErrNoRefreshToken)
+	}
+
+	tokensResponse, err := t.client.RefreshTokens(t.ctx, t.applicationKey, t.token.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	t.token = tokensResponse.OAuth2Token()
 
-	return &tokensResponse, err
+	return t.token, nil
 }
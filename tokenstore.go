@@ -0,0 +1,204 @@
+package ecobee
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrTokenNotFound is returned by a TokenStore's Load method when no token has
+// been saved yet.
+var ErrTokenNotFound = errors.New("token not found")
+
+// A TokenStore persists OAuth2 tokens across process restarts.
+//
+// ecobee rotates the refresh token every time the access token is refreshed,
+// and the previous refresh token stops working as soon as the new one is
+// issued. A TokenStore configured with WithTokenStore is therefore handed
+// every rotated token the moment it is issued.
+//
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Load returns the most recently saved token. It returns an error
+	// satisfying errors.Is(err, ErrTokenNotFound) if no token was saved yet.
+	Load() (*oauth2.Token, error)
+	// Save persists token, replacing any previously saved token.
+	Save(token *oauth2.Token) error
+}
+
+// FileTokenStore is a TokenStore that persists the token to a file in JSON
+// format. Tokens are written atomically with 0600 permissions.
+type FileTokenStore struct {
+	filename string
+	mu       sync.Mutex
+}
+
+// NewFileTokenStore returns a FileTokenStore persisting the token to filename.
+func NewFileTokenStore(filename string) *FileTokenStore {
+	return &FileTokenStore{
+		filename: filename,
+	}
+}
+
+// Load implements the TokenStore interface.
+func (f *FileTokenStore) Load() (*oauth2.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := ioutil.ReadFile(f.filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: %w", f.filename, ErrTokenNotFound)
+		}
+
+		return nil, err
+	}
+
+	token := oauth2.Token{}
+
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.filename, err)
+	}
+
+	return &token, nil
+}
+
+// Save implements the TokenStore interface. The token is first written to a
+// temporary file in the same directory which is then renamed over filename,
+// so a crash never leaves a partially written token behind.
+func (f *FileTokenStore) Save(token *oauth2.Token) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := json.MarshalIndent(token, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(f.filename), filepath.Base(f.filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	tempFilename := file.Name()
+
+	defer func() {
+		_ = os.Remove(tempFilename)
+	}()
+
+	if err := file.Chmod(0600); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFilename, f.filename)
+}
+
+// MemoryTokenStore is a TokenStore that keeps the token in memory. It is
+// mostly useful for tests and short-lived processes.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// NewMemoryTokenStore returns a MemoryTokenStore initialized with token, which
+// may be nil.
+func NewMemoryTokenStore(token *oauth2.Token) *MemoryTokenStore {
+	m := &MemoryTokenStore{}
+
+	if token != nil {
+		tokenCopy := *token
+		m.token = &tokenCopy
+	}
+
+	return m
+}
+
+// Load implements the TokenStore interface.
+func (m *MemoryTokenStore) Load() (*oauth2.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token == nil {
+		return nil, ErrTokenNotFound
+	}
+
+	tokenCopy := *m.token
+
+	return &tokenCopy, nil
+}
+
+// Save implements the TokenStore interface.
+func (m *MemoryTokenStore) Save(token *oauth2.Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tokenCopy := *token
+	m.token = &tokenCopy
+
+	return nil
+}
+
+type persistingTokenSource struct {
+	source oauth2.TokenSource
+	store  TokenStore
+
+	mu        sync.Mutex
+	lastSaved *oauth2.Token
+}
+
+func newPersistingTokenSource(source oauth2.TokenSource, store TokenStore, initial *oauth2.Token) oauth2.TokenSource {
+	return &persistingTokenSource{
+		source:    source,
+		store:     store,
+		lastSaved: initial,
+	}
+}
+
+// Token implements the oauth2.TokenSource interface. Every token that differs
+// from the last successfully saved one is saved before being returned. If
+// saving fails the error is returned and saving is retried on the next call.
+func (p *persistingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := p.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.lastSaved != nil && p.lastSaved.AccessToken == token.AccessToken && p.lastSaved.RefreshToken == token.RefreshToken {
+		return token, nil
+	}
+
+	if err := p.store.Save(token); err != nil {
+		return nil, fmt.Errorf("token store: %w", err)
+	}
+
+	p.lastSaved = token
+
+	return token, nil
+}
@@ -0,0 +1,179 @@
+package ecobee
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxMinOnTime  = 60
+	maxReportDays = 31
+	maxInterval   = 287
+)
+
+// A FieldError describes a missing or invalid request parameter.
+type FieldError struct {
+	field  string
+	reason string
+}
+
+// Error returns the string representation of a FieldError.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.field, e.reason)
+}
+
+// Field returns the ecobee name of the parameter.
+func (e FieldError) Field() string {
+	return e.field
+}
+
+// Reason returns why the parameter is invalid.
+func (e FieldError) Reason() string {
+	return e.reason
+}
+
+// A ValidationError lists every missing or invalid parameter of a request
+// detected before it is sent. It matches ErrValidation through errors.Is, like
+// an APIError returned by the ecobee server for an invalid request.
+type ValidationError struct {
+	name        string
+	fieldErrors []FieldError
+}
+
+// Error returns the string representation of a ValidationError.
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.fieldErrors))
+
+	for i, fieldError := range e.fieldErrors {
+		messages[i] = fieldError.Error()
+	}
+
+	return fmt.Sprintf("%s: invalid parameters: %s", e.name, strings.Join(messages, "; "))
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Name returns the name of the function or endpoint whose parameters are
+// invalid.
+func (e *ValidationError) Name() string {
+	return e.name
+}
+
+// FieldErrors returns the missing or invalid parameters.
+func (e *ValidationError) FieldErrors() []FieldError {
+	return append([]FieldError(nil), e.fieldErrors...)
+}
+
+// A validator collects the FieldErrors of a request's parameters.
+type validator struct {
+	name        string
+	fieldErrors []FieldError
+}
+
+func newValidator(name string) *validator {
+	return &validator{name: name}
+}
+
+// check records a FieldError for field if ok is false.
+func (v *validator) check(ok bool, field string, format string, args ...interface{}) {
+	if !ok {
+		v.fieldErrors = append(v.fieldErrors, FieldError{
+			field:  field,
+			reason: fmt.Sprintf(format, args...),
+		})
+	}
+}
+
+// required records a FieldError for field if it is not set.
+func (v *validator) required(set bool, field string) {
+	v.check(set, field, "required")
+}
+
+// intRange records a FieldError for field if it is set and outside
+// [min, max].
+func (v *validator) intRange(value *int, field string, min int, max int) {
+	if value != nil {
+		v.check(*value >= min && *value <= max, field, "%d is out of range [%d, %d]", *value, min, max)
+	}
+}
+
+// numericStringRange records a FieldError for field if it is set and is not
+// an integer in [min, max].
+func (v *validator) numericStringRange(value *string, field string, min int, max int) {
+	if value == nil {
+		return
+	}
+
+	n, err := strconv.Atoi(*value)
+	if err != nil {
+		v.check(false, field, "%q is not an integer", *value)
+
+		return
+	}
+
+	v.intRange(&n, field, min, max)
+}
+
+// hold records the FieldErrors of the hold duration parameters shared by the
+// functions setting a hold. holdHours is required when holdType is holdHours
+// and the end date & time when holdType is dateTime. Parameters the holdType
+// does not use are left for ecobee to ignore.
+func (v *validator) hold(holdType *HoldType, holdHours *int, startDateTime *time.Time, endDateTime *time.Time) {
+	if holdType != nil {
+		v.check(holdType.IsValid(), "holdType", "invalid value %q", *holdType)
+
+		switch *holdType {
+		case HoldTypeDateTime:
+			v.check(endDateTime != nil, "endDate", "required when holdType is %s", *holdType)
+		case HoldTypeHoldHours:
+			v.check(holdHours != nil, "holdHours", "required when holdType is %s", *holdType)
+		}
+	}
+
+	if holdHours != nil {
+		v.check(*holdHours > 0, "holdHours", "%d must be positive", *holdHours)
+	}
+
+	v.dateTimeRange(startDateTime, endDateTime)
+}
+
+// dateTimeRange records a FieldError if the end date & time is not after the
+// start date & time.
+func (v *validator) dateTimeRange(startDateTime *time.Time, endDateTime *time.Time) {
+	if startDateTime != nil && endDateTime != nil {
+		v.check(endDateTime.After(*startDateTime), "endDate", "must be after startDate")
+	}
+}
+
+// reportDates records the FieldErrors of the date range parameters shared by
+// the reports. The start and end dates are required, the intervals are in
+// [0, 287] and the range spans at most 31 days.
+func (v *validator) reportDates(startDate *time.Time, startInterval *int, endDate *time.Time, endInterval *int) {
+	v.required(startDate != nil, "startDate")
+	v.required(endDate != nil, "endDate")
+	v.intRange(startInterval, "startInterval", 0, maxInterval)
+	v.intRange(endInterval, "endInterval", 0, maxInterval)
+
+	if startDate != nil && endDate != nil {
+		v.check(!endDate.Before(*startDate), "endDate", "must not be before startDate")
+		v.check(endDate.Sub(*startDate) <= maxReportDays*24*time.Hour, "endDate", "must be at most %d days after startDate", maxReportDays)
+	}
+}
+
+// err returns a *ValidationError listing the recorded FieldErrors, or nil if
+// there are none.
+func (v *validator) err() error {
+	if len(v.fieldErrors) == 0 {
+		return nil
+	}
+
+	return &ValidationError{
+		name:        v.name,
+		fieldErrors: v.fieldErrors,
+	}
+}
@@ -0,0 +1,103 @@
+package ecobee
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidatorHold(t *testing.T) {
+	startDateTime := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	endDateTime := time.Date(2024, time.January, 9, 22, 30, 0, 0, time.UTC)
+	holdTypeHoldHours := HoldTypeHoldHours
+	holdTypeDateTime := HoldTypeDateTime
+	holdTypeIndefinite := HoldTypeIndefinite
+
+	tests := []struct {
+		name          string
+		holdType      *HoldType
+		holdHours     *int
+		startDateTime *time.Time
+		endDateTime   *time.Time
+		wantFields    []string
+	}{
+		{
+			name: "no hold parameters",
+		},
+		{
+			name:      "hold hours",
+			holdType:  &holdTypeHoldHours,
+			holdHours: Int(2),
+		},
+		{
+			name:       "hold hours missing",
+			holdType:   &holdTypeHoldHours,
+			wantFields: []string{"holdHours"},
+		},
+		{
+			name:       "hold hours not positive",
+			holdType:   &holdTypeHoldHours,
+			holdHours:  Int(0),
+			wantFields: []string{"holdHours"},
+		},
+		{
+			name:        "date time",
+			holdType:    &holdTypeDateTime,
+			endDateTime: &endDateTime,
+		},
+		{
+			name:       "end date missing",
+			holdType:   &holdTypeDateTime,
+			wantFields: []string{"endDate"},
+		},
+		{
+			name:          "end date before start date",
+			holdType:      &holdTypeDateTime,
+			startDateTime: &endDateTime,
+			endDateTime:   &startDateTime,
+			wantFields:    []string{"endDate"},
+		},
+		{
+			name:        "parameters unused by the hold type are allowed",
+			holdType:    &holdTypeIndefinite,
+			holdHours:   Int(2),
+			endDateTime: &endDateTime,
+		},
+		{
+			name:      "hold hours without hold type is allowed",
+			holdHours: Int(2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := newValidator("setHold")
+			v.hold(test.holdType, test.holdHours, test.startDateTime, test.endDateTime)
+
+			err := v.err()
+			if len(test.wantFields) == 0 {
+				if err != nil {
+					t.Fatalf("hold() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			validationError := &ValidationError{}
+			if !errors.As(err, &validationError) {
+				t.Fatalf("hold() error = %v, want a *ValidationError", err)
+			}
+
+			fields := []string{}
+
+			for _, fieldError := range validationError.FieldErrors() {
+				fields = append(fields, fieldError.Field())
+			}
+
+			if strings.Join(fields, ",") != strings.Join(test.wantFields, ",") {
+				t.Errorf("FieldErrors() fields = %q, want %q", fields, test.wantFields)
+			}
+		})
+	}
+}
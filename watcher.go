@@ -0,0 +1,323 @@
+package ecobee
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+const (
+	defaultWatcherPollInterval = 3 * time.Minute
+	defaultWatcherMaxBackoff   = 30 * time.Minute
+)
+
+// A WatcherEventType specifies the type of a WatcherEvent.
+type WatcherEventType string
+
+// Supported WatcherEventType values.
+const (
+	// The thermostat program, hvac mode, settings or configuration changed.
+	WatcherEventTypeConfigChanged WatcherEventType = "configChanged"
+	// The thermostat runtime or interval data changed.
+	WatcherEventTypeRuntimeChanged WatcherEventType = "runtimeChanged"
+	// An alert was issued or acknowledged.
+	WatcherEventTypeAlertsChanged WatcherEventType = "alertsChanged"
+	// The thermostat connected to the ecobee servers.
+	WatcherEventTypeConnected WatcherEventType = "connected"
+	// The thermostat disconnected from the ecobee servers.
+	WatcherEventTypeDisconnected WatcherEventType = "disconnected"
+)
+
+// A WatcherEvent describes a change of a thermostat detected by a Watcher.
+type WatcherEvent struct {
+	// The type of the change.
+	Type WatcherEventType
+	// The thermostat identifier.
+	Identifier string
+	// The thermostat revisions after the change.
+	Revision ThermostatRevision
+	// The thermostat data retrieved after the change, including the objects
+	// requested by the Watcher's selection for the event type. It is nil when
+	// the Watcher retrieves no data for the event type.
+	Thermostat *objects.Thermostat
+}
+
+type watcherOptionalParameters func(*Watcher)
+
+// A Watcher polls ThermostatSummary and retrieves thermostat data only for
+// the thermostats whose revisions changed, as recommended by ecobee.
+//
+// For more information see: https://www.ecobee.com/home/developer/api/documentation/v1/operations/get-thermostat-summary.shtml
+type Watcher struct {
+	client       *Client
+	selection    *objects.Selection
+	selections   map[WatcherEventType]*objects.Selection
+	pollInterval time.Duration
+	maxBackoff   time.Duration
+	errorHandler func(error)
+}
+
+// NewWatcher initializes a new Watcher. selection specifies the thermostats to
+// watch and the objects to retrieve when the configuration, runtime or alerts
+// of a thermostat change (e.g. IncludeRuntime, IncludeSettings). No data is
+// retrieved when a thermostat only connects or disconnects; use
+// WithWatcherSelection to change the objects retrieved per event type.
+// pollInterval specifies how often
+// ThermostatSummary is polled; ecobee asks applications not to poll more often
+// than every 3 minutes. A non-positive pollInterval defaults to 3 minutes. It
+// takes functors to modify values when creating it.
+func NewWatcher(client *Client, selection *objects.Selection, pollInterval time.Duration, optionalParameters ...watcherOptionalParameters) *Watcher {
+	watcher := &Watcher{
+		client:    client,
+		selection: selection,
+		selections: map[WatcherEventType]*objects.Selection{
+			WatcherEventTypeConfigChanged:  selection,
+			WatcherEventTypeRuntimeChanged: selection,
+			WatcherEventTypeAlertsChanged:  selection,
+		},
+		pollInterval: pollInterval,
+		maxBackoff:   defaultWatcherMaxBackoff,
+		errorHandler: func(error) {},
+	}
+
+	for _, optionalParameter := range optionalParameters {
+		optionalParameter(watcher)
+	}
+
+	if watcher.pollInterval <= 0 {
+		watcher.pollInterval = defaultWatcherPollInterval
+	}
+
+	if watcher.maxBackoff < watcher.pollInterval {
+		watcher.maxBackoff = watcher.pollInterval
+	}
+
+	return watcher
+}
+
+// WithWatcherErrorHandler returns a function that initializes a Watcher with
+// a function called with every error encountered while polling. The Watcher
+// keeps polling after an error.
+func WithWatcherErrorHandler(errorHandler func(error)) func(*Watcher) {
+	return func(w *Watcher) {
+		w.errorHandler = errorHandler
+	}
+}
+
+// WithWatcherSelection returns a function that initializes a Watcher with the
+// objects to retrieve for the events of eventType. Only the include flags of
+// selection are used; the watched thermostats are always those of the
+// Watcher's selection. A nil selection retrieves no data for eventType.
+// Thermostats changed in several ways are retrieved once per distinct
+// selection.
+func WithWatcherSelection(eventType WatcherEventType, selection *objects.Selection) func(*Watcher) {
+	return func(w *Watcher) {
+		w.selections[eventType] = selection
+	}
+}
+
+// WithWatcherMaxBackoff returns a function that initializes a Watcher with the
+// upper bound of the wait between polls after consecutive errors. After an
+// error the wait doubles from the poll interval up to maxBackoff. It defaults
+// to 30 minutes and is never less than the poll interval.
+func WithWatcherMaxBackoff(maxBackoff time.Duration) func(*Watcher) {
+	return func(w *Watcher) {
+		w.maxBackoff = maxBackoff
+	}
+}
+
+// Run polls until ctx is done and calls handler with every event, from the
+// calling goroutine. The first poll reports every watched thermostat as
+// changed so handler receives their initial data. Run returns ctx's error.
+func (w *Watcher) Run(ctx context.Context, handler func(WatcherEvent)) error {
+	revisions := map[string]ThermostatRevision{}
+	failures := 0
+
+	for {
+		events, currentRevisions, err := w.poll(ctx, revisions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			w.errorHandler(err)
+
+			failures++
+		} else {
+			revisions = currentRevisions
+			failures = 0
+
+			for _, event := range events {
+				handler(event)
+			}
+		}
+
+		if err := sleep(ctx, w.wait(failures)); err != nil {
+			return err
+		}
+	}
+}
+
+// Events starts polling in a new goroutine and returns a channel receiving
+// every event. The channel is closed once ctx is done.
+func (w *Watcher) Events(ctx context.Context) <-chan WatcherEvent {
+	events := make(chan WatcherEvent)
+
+	go func() {
+		defer close(events)
+
+		_ = w.Run(ctx, func(event WatcherEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return events
+}
+
+func (w *Watcher) wait(failures int) time.Duration {
+	wait := w.pollInterval
+
+	for i := 0; i < failures && wait < w.maxBackoff; i++ {
+		wait *= 2
+	}
+
+	if failures > 0 && wait > w.maxBackoff {
+		wait = w.maxBackoff
+	}
+
+	return wait
+}
+
+// poll returns the events since previous and the current revisions. The
+// current revisions must only replace previous if no error is returned, so
+// changes are detected again on the next poll.
+func (w *Watcher) poll(ctx context.Context, previous map[string]ThermostatRevision) ([]WatcherEvent, map[string]ThermostatRevision, error) {
+	summarySelection := objects.Selection{
+		SelectionType:  w.selection.SelectionType,
+		SelectionMatch: w.selection.SelectionMatch,
+	}
+
+	summaryResponse, err := w.client.ThermostatSummary(ctx, &summarySelection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current, err := summaryResponse.Revisions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := CompareRevisions(previous, current)
+	if len(changes) == 0 {
+		return nil, current, nil
+	}
+
+	identifiers := make([]string, 0, len(changes))
+	for identifier := range changes {
+		identifiers = append(identifiers, identifier)
+	}
+
+	sort.Strings(identifiers)
+
+	eventTypes := make(map[string][]WatcherEventType, len(identifiers))
+
+	// The identifiers to retrieve per selection, in the order the selections
+	// are first needed.
+	selections := []*objects.Selection{}
+	selectionIdentifiers := map[*objects.Selection][]string{}
+
+	for _, identifier := range identifiers {
+		eventTypes[identifier] = watcherEventTypes(changes[identifier], current[identifier])
+
+		for _, eventType := range eventTypes[identifier] {
+			selection := w.selections[eventType]
+			if selection == nil {
+				continue
+			}
+
+			if _, ok := selectionIdentifiers[selection]; !ok {
+				selections = append(selections, selection)
+			}
+
+			if n := len(selectionIdentifiers[selection]); n == 0 || selectionIdentifiers[selection][n-1] != identifier {
+				selectionIdentifiers[selection] = append(selectionIdentifiers[selection], identifier)
+			}
+		}
+	}
+
+	thermostatsBySelection := make(map[*objects.Selection]map[string]*objects.Thermostat, len(selections))
+
+	for _, selection := range selections {
+		thermostatSelection := *selection
+		thermostatSelection.SelectionType = String("thermostats")
+		thermostatSelection.SelectionMatch = String(strings.Join(selectionIdentifiers[selection], ","))
+
+		thermostats, err := w.client.ThermostatsAll(ctx, &thermostatSelection)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		thermostatsByIdentifier := make(map[string]*objects.Thermostat, len(thermostats))
+
+		for i := range thermostats {
+			if thermostats[i].Identifier != nil {
+				thermostatsByIdentifier[*thermostats[i].Identifier] = &thermostats[i]
+			}
+		}
+
+		thermostatsBySelection[selection] = thermostatsByIdentifier
+	}
+
+	events := []WatcherEvent{}
+
+	for _, identifier := range identifiers {
+		for _, eventType := range eventTypes[identifier] {
+			event := WatcherEvent{
+				Type:       eventType,
+				Identifier: identifier,
+				Revision:   current[identifier],
+			}
+
+			if selection := w.selections[eventType]; selection != nil {
+				event.Thermostat = thermostatsBySelection[selection][identifier]
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	return events, current, nil
+}
+
+// watcherEventTypes returns the types of the events describing changes.
+func watcherEventTypes(changes RevisionChanges, revision ThermostatRevision) []WatcherEventType {
+	eventTypes := []WatcherEventType{}
+
+	if changes.Connected {
+		if revision.Connected {
+			eventTypes = append(eventTypes, WatcherEventTypeConnected)
+		} else {
+			eventTypes = append(eventTypes, WatcherEventTypeDisconnected)
+		}
+	}
+
+	if changes.Thermostat {
+		eventTypes = append(eventTypes, WatcherEventTypeConfigChanged)
+	}
+
+	if changes.Runtime || changes.Interval {
+		eventTypes = append(eventTypes, WatcherEventTypeRuntimeChanged)
+	}
+
+	if changes.Alerts {
+		eventTypes = append(eventTypes, WatcherEventTypeAlertsChanged)
+	}
+
+	return eventTypes
+}
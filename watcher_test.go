@@ -0,0 +1,217 @@
+package ecobee
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sherif-fanous/go-ecobee/objects"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	connected := ThermostatRevision{Identifier: "1", Name: "One", Connected: true, ThermostatRevision: "t1", AlertsRevision: "a1", RuntimeRevision: "r1", IntervalRevision: "i1"}
+
+	with := func(modify func(r *ThermostatRevision)) ThermostatRevision {
+		r := connected
+		modify(&r)
+
+		return r
+	}
+
+	runtimeSelection := &objects.Selection{IncludeRuntime: Bool(true)}
+
+	tests := []struct {
+		name               string
+		optionalParameters []watcherOptionalParameters
+		previous           map[string]ThermostatRevision
+		current            ThermostatRevision
+		wantEvents         []WatcherEventType
+		wantRequests       []string
+	}{
+		{
+			name:         "first poll",
+			previous:     map[string]ThermostatRevision{},
+			current:      connected,
+			wantEvents:   []WatcherEventType{WatcherEventTypeConfigChanged, WatcherEventTypeRuntimeChanged, WatcherEventTypeAlertsChanged},
+			wantRequests: []string{"1:settings"},
+		},
+		{
+			name:     "unchanged",
+			previous: map[string]ThermostatRevision{"1": connected},
+			current:  connected,
+		},
+		{
+			name:       "disconnected",
+			previous:   map[string]ThermostatRevision{"1": connected},
+			current:    with(func(r *ThermostatRevision) { r.Connected = false }),
+			wantEvents: []WatcherEventType{WatcherEventTypeDisconnected},
+		},
+		{
+			name:         "connected with new runtime",
+			previous:     map[string]ThermostatRevision{"1": with(func(r *ThermostatRevision) { r.Connected = false })},
+			current:      with(func(r *ThermostatRevision) { r.IntervalRevision = "i2" }),
+			wantEvents:   []WatcherEventType{WatcherEventTypeConnected, WatcherEventTypeRuntimeChanged},
+			wantRequests: []string{"1:settings"},
+		},
+		{
+			name:               "selection per event type",
+			optionalParameters: []watcherOptionalParameters{WithWatcherSelection(WatcherEventTypeRuntimeChanged, runtimeSelection)},
+			previous:           map[string]ThermostatRevision{"1": connected},
+			current: with(func(r *ThermostatRevision) {
+				r.ThermostatRevision, r.RuntimeRevision, r.AlertsRevision = "t2", "r2", "a2"
+			}),
+			wantEvents:   []WatcherEventType{WatcherEventTypeConfigChanged, WatcherEventTypeRuntimeChanged, WatcherEventTypeAlertsChanged},
+			wantRequests: []string{"1:settings", "1:runtime"},
+		},
+		{
+			name:               "selection for connection changes",
+			optionalParameters: []watcherOptionalParameters{WithWatcherSelection(WatcherEventTypeDisconnected, runtimeSelection)},
+			previous:           map[string]ThermostatRevision{"1": connected},
+			current:            with(func(r *ThermostatRevision) { r.Connected = false }),
+			wantEvents:         []WatcherEventType{WatcherEventTypeDisconnected},
+			wantRequests:       []string{"1:runtime"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := []string{}
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/"+thermostatSummaryEndpoint) {
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{
+						"revisionList": []string{strings.Join([]string{
+							test.current.Identifier,
+							test.current.Name,
+							strconv.FormatBool(test.current.Connected),
+							test.current.ThermostatRevision,
+							test.current.AlertsRevision,
+							test.current.RuntimeRevision,
+							test.current.IntervalRevision,
+						}, ":")},
+						"thermostatCount": 1,
+						"status":          objects.Status{Code: Int(0), Message: String("")},
+					})
+
+					return
+				}
+
+				request := struct {
+					Selection objects.Selection `json:"selection"`
+				}{}
+
+				if err := json.Unmarshal([]byte(r.URL.Query().Get("json")), &request); err != nil {
+					t.Errorf("json.Unmarshal() error = %v", err)
+				}
+
+				include := "settings"
+				if request.Selection.IncludeRuntime != nil && *request.Selection.IncludeRuntime {
+					include = "runtime"
+				}
+
+				requests = append(requests, *request.Selection.SelectionMatch+":"+include)
+
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"page":           objects.Page{Page: Int(1), TotalPages: Int(1), PageSize: Int(1), Total: Int(1)},
+					"thermostatList": []objects.Thermostat{{Identifier: String(*request.Selection.SelectionMatch)}},
+					"status":         objects.Status{Code: Int(0), Message: String("")},
+				})
+			})
+
+			watcher := NewWatcher(client, &objects.Selection{SelectionType: String("registered"), IncludeSettings: Bool(true)}, 0, test.optionalParameters...)
+
+			events, current, err := watcher.poll(context.Background(), test.previous)
+			if err != nil {
+				t.Fatalf("poll() error = %v", err)
+			}
+
+			if current["1"] != test.current {
+				t.Errorf("poll() revisions = %+v, want %+v", current["1"], test.current)
+			}
+
+			if len(events) != len(test.wantEvents) {
+				t.Fatalf("poll() = %+v, want events %v", events, test.wantEvents)
+			}
+
+			for i, event := range events {
+				if event.Type != test.wantEvents[i] || event.Identifier != "1" || event.Revision != test.current {
+					t.Errorf("poll()[%d] = %+v, want a %s event for thermostat 1", i, event, test.wantEvents[i])
+				}
+
+				if wantThermostat := watcher.selections[event.Type] != nil; (event.Thermostat != nil) != wantThermostat {
+					t.Errorf("poll()[%d].Thermostat = %v, want thermostat data %t", i, event.Thermostat, wantThermostat)
+				}
+			}
+
+			if strings.Join(requests, "|") != strings.Join(test.wantRequests, "|") {
+				t.Errorf("thermostat requests = %q, want %q", requests, test.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWatcherWait(t *testing.T) {
+	tests := []struct {
+		name         string
+		pollInterval time.Duration
+		maxBackoff   time.Duration
+		failures     int
+		want         time.Duration
+	}{
+		{
+			name:         "no failures",
+			pollInterval: 3 * time.Minute,
+			maxBackoff:   30 * time.Minute,
+			want:         3 * time.Minute,
+		},
+		{
+			name:         "doubles after a failure",
+			pollInterval: 3 * time.Minute,
+			maxBackoff:   30 * time.Minute,
+			failures:     1,
+			want:         6 * time.Minute,
+		},
+		{
+			name:         "doubles after every failure",
+			pollInterval: 3 * time.Minute,
+			maxBackoff:   30 * time.Minute,
+			failures:     3,
+			want:         24 * time.Minute,
+		},
+		{
+			name:         "capped at the max backoff",
+			pollInterval: 3 * time.Minute,
+			maxBackoff:   30 * time.Minute,
+			failures:     4,
+			want:         30 * time.Minute,
+		},
+		{
+			name:         "capped after many failures",
+			pollInterval: 3 * time.Minute,
+			maxBackoff:   30 * time.Minute,
+			failures:     100,
+			want:         30 * time.Minute,
+		},
+		{
+			name:         "max backoff below the poll interval",
+			pollInterval: 3 * time.Minute,
+			maxBackoff:   time.Minute,
+			failures:     2,
+			want:         3 * time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			watcher := NewWatcher(NewClient(), &objects.Selection{}, test.pollInterval, WithWatcherMaxBackoff(test.maxBackoff))
+
+			if got := watcher.wait(test.failures); got != test.want {
+				t.Errorf("wait(%d) = %s, want %s", test.failures, got, test.want)
+			}
+		})
+	}
+}